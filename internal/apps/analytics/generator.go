@@ -62,9 +62,9 @@ type Generator struct {
 }
 
 // NewGenerator creates a new analytics data generator.
-func NewGenerator() *Generator {
+func NewGenerator(seed uint64) *Generator {
 	return &Generator{
-		faker: datagen.NewFaker(),
+		faker: datagen.NewFakerWithSeed(seed),
 		cfg:   datagen.DefaultBatchConfig(),
 	}
 }
@@ -18,33 +18,10 @@ import (
 	"github.com/pgEdge/pgedge-loadgen/internal/datagen"
 )
 
-// Query weights - TPC-H queries with realistic OLAP distribution
-// Weights adjusted based on query execution time to prevent resource contention
-var queryWeights = map[string]int{
-	"pricing_summary":       0,  // Q1 - Disabled: Heavy aggregation (~7s) causes timeouts
-	"min_cost_supplier":     5,  // Q2
-	"shipping_priority":     8,  // Q3
-	"order_priority":        6,  // Q4
-	"local_supplier_volume": 5,  // Q5
-	"revenue_forecast":      10, // Q6 - Simple, fast
-	"volume_shipping":       4,  // Q7
-	"market_share":          2,  // Q8 - Complex join (~2.3s), reduced weight
-	"product_profit":        5,  // Q9
-	"returned_items":        6,  // Q10
-	"important_stock":       4,  // Q11
-	"shipping_modes":        4,  // Q12 - Reduced weight (~2.2s)
-	"customer_distribution": 5,  // Q13
-	"promotion_effect":      6,  // Q14
-	"top_supplier":          4,  // Q15
-	"parts_supplier":        3,  // Q16
-	"small_quantity":        0,  // Q17 - Disabled: correlated subquery is too slow
-	"large_volume":          0,  // Q18 - Disabled: Very slow (~3.7s) causes timeouts
-	"discounted_revenue":    2,  // Q19 - Complex conditionals (~2.3s)
-}
-
 // QueryExecutor executes analytics queries.
 type QueryExecutor struct {
 	faker        *datagen.Faker
+	mix          *apps.QueryMix
 	numSuppliers int
 	numParts     int
 	numCustomers int
@@ -52,9 +29,10 @@ type QueryExecutor struct {
 }
 
 // NewQueryExecutor creates a new query executor.
-func NewQueryExecutor(numSuppliers, numParts, numCustomers, numOrders int) *QueryExecutor {
+func NewQueryExecutor(mix *apps.QueryMix, seed uint64, numSuppliers, numParts, numCustomers, numOrders int) *QueryExecutor {
 	return &QueryExecutor{
-		faker:        datagen.NewFaker(),
+		faker:        datagen.NewFakerWithSeed(seed),
+		mix:          mix,
 		numSuppliers: max(1, numSuppliers),
 		numParts:     max(1, numParts),
 		numCustomers: max(1, numCustomers),
@@ -64,8 +42,11 @@ func NewQueryExecutor(numSuppliers, numParts, numCustomers, numOrders int) *Quer
 
 // ExecuteRandomQuery executes a random analytical query based on weights.
 func (e *QueryExecutor) ExecuteRandomQuery(ctx context.Context, db apps.DB) apps.QueryResult {
-	queryType := e.selectQueryType()
+	return e.ExecuteQuery(ctx, db, e.SelectQuery())
+}
 
+// ExecuteQuery executes the named query, e.g. to retry a failed query.
+func (e *QueryExecutor) ExecuteQuery(ctx context.Context, db apps.DB, queryType string) apps.QueryResult {
 	start := time.Now()
 	var err error
 	var rowsAffected int64
@@ -109,6 +90,8 @@ func (e *QueryExecutor) ExecuteRandomQuery(ctx context.Context, db apps.DB) apps
 		rowsAffected, err = e.executeLargeVolume(ctx, db)
 	case "discounted_revenue":
 		rowsAffected, err = e.executeDiscountedRevenue(ctx, db)
+	default:
+		err = fmt.Errorf("unknown query '%s'", queryType)
 	}
 
 	return apps.QueryResult{
@@ -119,14 +102,9 @@ func (e *QueryExecutor) ExecuteRandomQuery(ctx context.Context, db apps.DB) apps
 	}
 }
 
-func (e *QueryExecutor) selectQueryType() string {
-	types := make([]string, 0, len(queryWeights))
-	weights := make([]int, 0, len(queryWeights))
-	for k, v := range queryWeights {
-		types = append(types, k)
-		weights = append(weights, v)
-	}
-	return datagen.ChooseWeighted(e.faker, types, weights)
+// SelectQuery returns the name of a query chosen from the query mix.
+func (e *QueryExecutor) SelectQuery() string {
+	return e.mix.Choose(e.faker)
 }
 
 // Q1: Pricing Summary Report - aggregates lineitem data
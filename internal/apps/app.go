@@ -16,6 +16,8 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/pgEdge/pgedge-loadgen/internal/datagen"
 )
 
 // DB is an interface that both *pgxpool.Pool and *pgx.Conn satisfy.
@@ -45,6 +47,10 @@ type GeneratorConfig struct {
 
 	// OpenAIAPIKey is the API key for OpenAI embeddings.
 	OpenAIAPIKey string
+
+	// Seed seeds the random data generator. The same seed and target size
+	// generate the same data, with dates relative to the time of generation.
+	Seed uint64
 }
 
 // QueryResult holds the result of a query execution.
@@ -85,16 +91,40 @@ type App interface {
 	// GetQueries returns the available queries for this application.
 	GetQueries() []QueryDefinition
 
-	// ExecuteQuery executes a randomly selected query based on the query mix.
-	ExecuteQuery(ctx context.Context, pool *pgxpool.Pool) QueryResult
+	// GetTables returns the tables in the application's schema.
+	GetTables() []TableDefinition
 
-	// ExecuteQueryConn executes a randomly selected query using a single connection.
-	ExecuteQueryConn(ctx context.Context, conn *pgx.Conn) QueryResult
+	// SetQueryWeights overrides the relative weights of the named queries.
+	// It must be called before the first query executor is created.
+	SetQueryWeights(weights map[string]int)
+
+	// NewQueryExecutor creates a query executor for a single worker, with
+	// its own random number generator seeded with seed. The row counts the
+	// queries are parameterized with are looked up using db when the first
+	// executor is created and shared between executors.
+	NewQueryExecutor(ctx context.Context, db DB, seed uint64) QueryExecutor
 
 	// RequiresPgvector returns true if the app needs pgvector extension.
 	RequiresPgvector() bool
 }
 
+// QueryExecutor executes queries on behalf of a single worker. It is not
+// safe for concurrent use; each worker creates its own.
+type QueryExecutor interface {
+	// ExecuteRandomQuery executes a randomly selected query based on the
+	// query mix.
+	ExecuteRandomQuery(ctx context.Context, db DB) QueryResult
+
+	// SelectQuery returns the name of a query chosen from the query mix,
+	// to be run with ExecuteQuery. It draws from the same random number
+	// generator as ExecuteRandomQuery.
+	SelectQuery() string
+
+	// ExecuteQuery executes the named query with new random parameters,
+	// e.g. to retry a query that failed.
+	ExecuteQuery(ctx context.Context, db DB, name string) QueryResult
+}
+
 // QueryDefinition describes a query type in the application's workload.
 type QueryDefinition struct {
 	// Name is the query identifier.
@@ -103,7 +133,8 @@ type QueryDefinition struct {
 	// Description describes what the query does.
 	Description string
 
-	// Weight is the relative frequency of this query (0-100).
+	// Weight is the default relative frequency of this query (0-100).
+	// A weight of 0 disables the query unless it is overridden.
 	Weight int
 
 	// Type is the query type (read, write, mixed).
@@ -122,6 +153,24 @@ type TableDefinition struct {
 	// For example, if the base table has 1000 rows at scale 1,
 	// a table with ScaleRatio 10 would have 10000 rows.
 	ScaleRatio float64
+
+	// IndexFactor is the estimated index overhead (e.g., 1.3 = 30% overhead).
+	IndexFactor float64
+}
+
+// TablesFromSizes converts data generator table size information to table
+// definitions.
+func TablesFromSizes(sizes []datagen.TableSizeInfo) []TableDefinition {
+	tables := make([]TableDefinition, len(sizes))
+	for i, s := range sizes {
+		tables[i] = TableDefinition{
+			Name:        s.Name,
+			BaseRowSize: s.BaseRowSize,
+			ScaleRatio:  s.ScaleRatio,
+			IndexFactor: s.IndexFactor,
+		}
+	}
+	return tables
 }
 
 // SizeMaintainer is an optional interface that apps can implement to support
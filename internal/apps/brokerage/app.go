@@ -11,8 +11,8 @@ package brokerage
 
 import (
 	"context"
+	"sync"
 
-	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/pgEdge/pgedge-loadgen/internal/apps"
@@ -20,7 +20,18 @@ import (
 
 // App implements the brokerage firm application (TPC-E based).
 type App struct {
-	executor *QueryExecutor
+	mu           sync.Mutex
+	counts       *tableCounts // looked up by the first query executor
+	queryWeights map[string]int
+}
+
+// tableCounts holds the table row counts the queries are parameterized with.
+type tableCounts struct {
+	customers  int
+	accounts   int
+	securities int
+	trades     int
+	brokers    int
 }
 
 // New creates a new brokerage application.
@@ -56,7 +67,7 @@ func (a *App) DropSchema(ctx context.Context, pool *pgxpool.Pool) error {
 
 // GenerateData generates test data for the application.
 func (a *App) GenerateData(ctx context.Context, pool *pgxpool.Pool, cfg apps.GeneratorConfig) error {
-	gen := NewGenerator()
+	gen := NewGenerator(cfg.Seed)
 	return gen.GenerateData(ctx, pool, cfg.TargetSize)
 }
 
@@ -126,24 +137,29 @@ func (a *App) GetQueries() []apps.QueryDefinition {
 	}
 }
 
-// ExecuteQuery executes a randomly selected query based on the query mix.
-func (a *App) ExecuteQuery(ctx context.Context, pool *pgxpool.Pool) apps.QueryResult {
-	// Initialize executor if needed (lazy initialization to get counts)
-	if a.executor == nil {
-		numCustomers, numAccounts, numSecurities, numTrades, numBrokers := a.getTableCounts(ctx, pool)
-		a.executor = NewQueryExecutor(numCustomers, numAccounts, numSecurities, numTrades, numBrokers)
-	}
-	return a.executor.ExecuteRandomQuery(ctx, pool)
+// GetTables returns the tables in the application's schema.
+func (a *App) GetTables() []apps.TableDefinition {
+	return apps.TablesFromSizes(tableSizes)
 }
 
-// ExecuteQueryConn executes a randomly selected query using a single connection.
-func (a *App) ExecuteQueryConn(ctx context.Context, conn *pgx.Conn) apps.QueryResult {
-	// Initialize executor if needed (lazy initialization to get counts)
-	if a.executor == nil {
-		numCustomers, numAccounts, numSecurities, numTrades, numBrokers := a.getTableCountsConn(ctx, conn)
-		a.executor = NewQueryExecutor(numCustomers, numAccounts, numSecurities, numTrades, numBrokers)
+// SetQueryWeights overrides the relative weights of the named queries.
+func (a *App) SetQueryWeights(weights map[string]int) {
+	a.queryWeights = weights
+}
+
+// NewQueryExecutor creates a query executor for a single worker.
+func (a *App) NewQueryExecutor(ctx context.Context, db apps.DB, seed uint64) apps.QueryExecutor {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Look up the table counts once and share them between workers
+	if a.counts == nil {
+		a.counts = getTableCounts(ctx, db)
 	}
-	return a.executor.ExecuteRandomQuery(ctx, conn)
+
+	c := a.counts
+	return NewQueryExecutor(apps.NewQueryMix(a.GetQueries(), a.queryWeights), seed,
+		c.customers, c.accounts, c.securities, c.trades, c.brokers)
 }
 
 // RequiresPgvector returns true if the app needs pgvector extension.
@@ -151,32 +167,24 @@ func (a *App) RequiresPgvector() bool {
 	return false
 }
 
-func (a *App) getTableCounts(ctx context.Context, pool *pgxpool.Pool) (int, int, int, int, int) {
+func getTableCounts(ctx context.Context, db apps.DB) *tableCounts {
 	var numCustomers, numAccounts, numSecurities, numTrades, numBrokers int
 
-	_ = pool.QueryRow(ctx, "SELECT COUNT(*) FROM customer").Scan(&numCustomers)
-	_ = pool.QueryRow(ctx, "SELECT COUNT(*) FROM customer_account").Scan(&numAccounts)
-	_ = pool.QueryRow(ctx, "SELECT COUNT(*) FROM security").Scan(&numSecurities)
-	_ = pool.QueryRow(ctx, "SELECT COUNT(*) FROM trade").Scan(&numTrades)
-	_ = pool.QueryRow(ctx, "SELECT COUNT(*) FROM broker").Scan(&numBrokers)
-
-	return max(1, numCustomers), max(1, numAccounts), max(1, numSecurities),
-		max(1, numTrades), max(1, numBrokers)
-}
-
-func (a *App) getTableCountsConn(ctx context.Context, conn *pgx.Conn) (int, int, int, int, int) {
-	var numCustomers, numAccounts, numSecurities, numTrades, numBrokers int
-
-	_ = conn.QueryRow(ctx, "SELECT COUNT(*) FROM customer").Scan(&numCustomers)
-	_ = conn.QueryRow(ctx, "SELECT COUNT(*) FROM customer_account").Scan(&numAccounts)
-	_ = conn.QueryRow(ctx, "SELECT COUNT(*) FROM security").Scan(&numSecurities)
-	_ = conn.QueryRow(ctx, "SELECT COUNT(*) FROM trade").Scan(&numTrades)
-	_ = conn.QueryRow(ctx, "SELECT COUNT(*) FROM broker").Scan(&numBrokers)
-
-	return max(1, numCustomers), max(1, numAccounts), max(1, numSecurities),
-		max(1, numTrades), max(1, numBrokers)
+	_ = db.QueryRow(ctx, "SELECT COUNT(*) FROM customer").Scan(&numCustomers)
+	_ = db.QueryRow(ctx, "SELECT COUNT(*) FROM customer_account").Scan(&numAccounts)
+	_ = db.QueryRow(ctx, "SELECT COUNT(*) FROM security").Scan(&numSecurities)
+	_ = db.QueryRow(ctx, "SELECT COUNT(*) FROM trade").Scan(&numTrades)
+	_ = db.QueryRow(ctx, "SELECT COUNT(*) FROM broker").Scan(&numBrokers)
+
+	return &tableCounts{
+		customers:  max(1, numCustomers),
+		accounts:   max(1, numAccounts),
+		securities: max(1, numSecurities),
+		trades:     max(1, numTrades),
+		brokers:    max(1, numBrokers),
+	}
 }
 
 func init() {
-	apps.Register(New())
+	apps.Register("brokerage", func() apps.App { return New() })
 }
@@ -18,23 +18,10 @@ import (
 	"github.com/pgEdge/pgedge-loadgen/internal/datagen"
 )
 
-// Query weights based on TPC-E specification
-var queryWeights = map[string]int{
-	"broker_volume":     5,
-	"customer_position": 13,
-	"market_feed":       1,
-	"market_watch":      18,
-	"security_detail":   14,
-	"trade_lookup":      8,
-	"trade_order":       10,
-	"trade_result":      10,
-	"trade_status":      19,
-	"trade_update":      2,
-}
-
 // QueryExecutor executes brokerage queries.
 type QueryExecutor struct {
 	faker         *datagen.Faker
+	mix           *apps.QueryMix
 	numCustomers  int
 	numAccounts   int
 	numSecurities int
@@ -43,9 +30,10 @@ type QueryExecutor struct {
 }
 
 // NewQueryExecutor creates a new query executor.
-func NewQueryExecutor(numCustomers, numAccounts, numSecurities, numTrades, numBrokers int) *QueryExecutor {
+func NewQueryExecutor(mix *apps.QueryMix, seed uint64, numCustomers, numAccounts, numSecurities, numTrades, numBrokers int) *QueryExecutor {
 	return &QueryExecutor{
-		faker:         datagen.NewFaker(),
+		faker:         datagen.NewFakerWithSeed(seed),
+		mix:           mix,
 		numCustomers:  max(1, numCustomers),
 		numAccounts:   max(1, numAccounts),
 		numSecurities: max(1, numSecurities),
@@ -56,8 +44,11 @@ func NewQueryExecutor(numCustomers, numAccounts, numSecurities, numTrades, numBr
 
 // ExecuteRandomQuery executes a random query based on the TPC-E weights.
 func (e *QueryExecutor) ExecuteRandomQuery(ctx context.Context, db apps.DB) apps.QueryResult {
-	queryType := e.selectQueryType()
+	return e.ExecuteQuery(ctx, db, e.SelectQuery())
+}
 
+// ExecuteQuery executes the named query, e.g. to retry a failed query.
+func (e *QueryExecutor) ExecuteQuery(ctx context.Context, db apps.DB, queryType string) apps.QueryResult {
 	start := time.Now()
 	var err error
 	var rowsAffected int64
@@ -83,6 +74,8 @@ func (e *QueryExecutor) ExecuteRandomQuery(ctx context.Context, db apps.DB) apps
 		rowsAffected, err = e.executeTradeStatus(ctx, db)
 	case "trade_update":
 		rowsAffected, err = e.executeTradeUpdate(ctx, db)
+	default:
+		err = fmt.Errorf("unknown query '%s'", queryType)
 	}
 
 	return apps.QueryResult{
@@ -93,14 +86,9 @@ func (e *QueryExecutor) ExecuteRandomQuery(ctx context.Context, db apps.DB) apps
 	}
 }
 
-func (e *QueryExecutor) selectQueryType() string {
-	types := make([]string, 0, len(queryWeights))
-	weights := make([]int, 0, len(queryWeights))
-	for k, v := range queryWeights {
-		types = append(types, k)
-		weights = append(weights, v)
-	}
-	return datagen.ChooseWeighted(e.faker, types, weights)
+// SelectQuery returns the name of a query chosen from the query mix.
+func (e *QueryExecutor) SelectQuery() string {
+	return e.mix.Choose(e.faker)
 }
 
 // Broker Volume - Calculate total trade volume per broker
@@ -11,8 +11,8 @@ package docmgmt
 
 import (
 	"context"
+	"sync"
 
-	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/pgEdge/pgedge-loadgen/internal/apps"
@@ -21,8 +21,18 @@ import (
 
 // App implements the document management application with semantic search.
 type App struct {
-	executor *QueryExecutor
-	embedder embeddings.Embedder
+	mu           sync.Mutex
+	counts       *tableCounts // looked up by the first query executor
+	embedder     embeddings.Embedder
+	queryWeights map[string]int
+}
+
+// tableCounts holds the table row counts the queries are parameterized with.
+type tableCounts struct {
+	documents int
+	users     int
+	folders   int
+	chunks    int
 }
 
 // New creates a new document management application.
@@ -70,7 +80,7 @@ func (a *App) GenerateData(ctx context.Context, pool *pgxpool.Pool, cfg apps.Gen
 	})
 	a.embedder = embedder
 
-	gen := NewGenerator(embedder, cfg.EmbeddingDimensions)
+	gen := NewGenerator(embedder, cfg.EmbeddingDimensions, cfg.Seed)
 	return gen.GenerateData(ctx, pool, cfg.TargetSize)
 }
 
@@ -122,42 +132,37 @@ func (a *App) GetQueries() []apps.QueryDefinition {
 	}
 }
 
-// ExecuteQuery executes a randomly selected query based on the query mix.
-func (a *App) ExecuteQuery(ctx context.Context, pool *pgxpool.Pool) apps.QueryResult {
-	// Initialize executor if needed (lazy initialization to get counts)
-	if a.executor == nil {
-		numDocuments, numUsers, numFolders, numChunks := a.getTableCounts(ctx, pool)
-
-		// Initialize embedder with default if not set
-		if a.embedder == nil {
-			a.embedder = embeddings.NewEmbedder(embeddings.Config{
-				Mode:       "random",
-				Dimensions: 384,
-			})
-		}
+// GetTables returns the tables in the application's schema.
+func (a *App) GetTables() []apps.TableDefinition {
+	return apps.TablesFromSizes(tableSizes)
+}
 
-		a.executor = NewQueryExecutor(a.embedder, numDocuments, numUsers, numFolders, numChunks)
-	}
-	return a.executor.ExecuteRandomQuery(ctx, pool)
+// SetQueryWeights overrides the relative weights of the named queries.
+func (a *App) SetQueryWeights(weights map[string]int) {
+	a.queryWeights = weights
 }
 
-// ExecuteQueryConn executes a randomly selected query using a single connection.
-func (a *App) ExecuteQueryConn(ctx context.Context, conn *pgx.Conn) apps.QueryResult {
-	// Initialize executor if needed (lazy initialization to get counts)
-	if a.executor == nil {
-		numDocuments, numUsers, numFolders, numChunks := a.getTableCountsConn(ctx, conn)
+// NewQueryExecutor creates a query executor for a single worker.
+func (a *App) NewQueryExecutor(ctx context.Context, db apps.DB, seed uint64) apps.QueryExecutor {
+	a.mu.Lock()
+	defer a.mu.Unlock()
 
-		// Initialize embedder with default if not set
-		if a.embedder == nil {
-			a.embedder = embeddings.NewEmbedder(embeddings.Config{
-				Mode:       "random",
-				Dimensions: 384,
-			})
-		}
+	// Look up the table counts once and share them between workers
+	if a.counts == nil {
+		a.counts = getTableCounts(ctx, db)
+	}
 
-		a.executor = NewQueryExecutor(a.embedder, numDocuments, numUsers, numFolders, numChunks)
+	// Initialize embedder with default if not set
+	if a.embedder == nil {
+		a.embedder = embeddings.NewEmbedder(embeddings.Config{
+			Mode:       "random",
+			Dimensions: 384,
+		})
 	}
-	return a.executor.ExecuteRandomQuery(ctx, conn)
+
+	c := a.counts
+	return NewQueryExecutor(apps.NewQueryMix(a.GetQueries(), a.queryWeights), seed,
+		a.embedder, c.documents, c.users, c.folders, c.chunks)
 }
 
 // RequiresPgvector returns true if the app needs pgvector extension.
@@ -165,28 +170,22 @@ func (a *App) RequiresPgvector() bool {
 	return true
 }
 
-func (a *App) getTableCounts(ctx context.Context, pool *pgxpool.Pool) (int, int, int, int) {
+func getTableCounts(ctx context.Context, db apps.DB) *tableCounts {
 	var numDocuments, numUsers, numFolders, numChunks int
 
-	_ = pool.QueryRow(ctx, "SELECT COUNT(*) FROM document").Scan(&numDocuments)
-	_ = pool.QueryRow(ctx, "SELECT COUNT(*) FROM doc_user").Scan(&numUsers)
-	_ = pool.QueryRow(ctx, "SELECT COUNT(*) FROM folder").Scan(&numFolders)
-	_ = pool.QueryRow(ctx, "SELECT COUNT(*) FROM document_chunk").Scan(&numChunks)
+	_ = db.QueryRow(ctx, "SELECT COUNT(*) FROM document").Scan(&numDocuments)
+	_ = db.QueryRow(ctx, "SELECT COUNT(*) FROM doc_user").Scan(&numUsers)
+	_ = db.QueryRow(ctx, "SELECT COUNT(*) FROM folder").Scan(&numFolders)
+	_ = db.QueryRow(ctx, "SELECT COUNT(*) FROM document_chunk").Scan(&numChunks)
 
-	return max(1, numDocuments), max(1, numUsers), max(1, numFolders), max(1, numChunks)
-}
-
-func (a *App) getTableCountsConn(ctx context.Context, conn *pgx.Conn) (int, int, int, int) {
-	var numDocuments, numUsers, numFolders, numChunks int
-
-	_ = conn.QueryRow(ctx, "SELECT COUNT(*) FROM document").Scan(&numDocuments)
-	_ = conn.QueryRow(ctx, "SELECT COUNT(*) FROM doc_user").Scan(&numUsers)
-	_ = conn.QueryRow(ctx, "SELECT COUNT(*) FROM folder").Scan(&numFolders)
-	_ = conn.QueryRow(ctx, "SELECT COUNT(*) FROM document_chunk").Scan(&numChunks)
-
-	return max(1, numDocuments), max(1, numUsers), max(1, numFolders), max(1, numChunks)
+	return &tableCounts{
+		documents: max(1, numDocuments),
+		users:     max(1, numUsers),
+		folders:   max(1, numFolders),
+		chunks:    max(1, numChunks),
+	}
 }
 
 func init() {
-	apps.Register(New())
+	apps.Register("docmgmt", func() apps.App { return New() })
 }
@@ -19,20 +19,10 @@ import (
 	"github.com/pgEdge/pgedge-loadgen/internal/datagen/embeddings"
 )
 
-// Query weights for document management workload
-var queryWeights = map[string]int{
-	"semantic_search":   35,
-	"find_similar":      15,
-	"browse_folder":     15,
-	"document_retrieve": 15,
-	"version_history":   5,
-	"upload_update":     10,
-	"permission_check":  5,
-}
-
 // QueryExecutor executes docmgmt queries.
 type QueryExecutor struct {
 	faker        *datagen.Faker
+	mix          *apps.QueryMix
 	embedder     embeddings.Embedder
 	numDocuments int
 	numUsers     int
@@ -41,9 +31,10 @@ type QueryExecutor struct {
 }
 
 // NewQueryExecutor creates a new query executor.
-func NewQueryExecutor(embedder embeddings.Embedder, numDocuments, numUsers, numFolders, numChunks int) *QueryExecutor {
+func NewQueryExecutor(mix *apps.QueryMix, seed uint64, embedder embeddings.Embedder, numDocuments, numUsers, numFolders, numChunks int) *QueryExecutor {
 	return &QueryExecutor{
-		faker:        datagen.NewFaker(),
+		faker:        datagen.NewFakerWithSeed(seed),
+		mix:          mix,
 		embedder:     embedder,
 		numDocuments: max(1, numDocuments),
 		numUsers:     max(1, numUsers),
@@ -54,8 +45,11 @@ func NewQueryExecutor(embedder embeddings.Embedder, numDocuments, numUsers, numF
 
 // ExecuteRandomQuery executes a random query based on weights.
 func (e *QueryExecutor) ExecuteRandomQuery(ctx context.Context, db apps.DB) apps.QueryResult {
-	queryType := e.selectQueryType()
+	return e.ExecuteQuery(ctx, db, e.SelectQuery())
+}
 
+// ExecuteQuery executes the named query, e.g. to retry a failed query.
+func (e *QueryExecutor) ExecuteQuery(ctx context.Context, db apps.DB, queryType string) apps.QueryResult {
 	start := time.Now()
 	var err error
 	var rowsAffected int64
@@ -75,6 +69,8 @@ func (e *QueryExecutor) ExecuteRandomQuery(ctx context.Context, db apps.DB) apps
 		rowsAffected, err = e.executeUploadUpdate(ctx, db)
 	case "permission_check":
 		rowsAffected, err = e.executePermissionCheck(ctx, db)
+	default:
+		err = fmt.Errorf("unknown query '%s'", queryType)
 	}
 
 	return apps.QueryResult{
@@ -85,14 +81,9 @@ func (e *QueryExecutor) ExecuteRandomQuery(ctx context.Context, db apps.DB) apps
 	}
 }
 
-func (e *QueryExecutor) selectQueryType() string {
-	types := make([]string, 0, len(queryWeights))
-	weights := make([]int, 0, len(queryWeights))
-	for k, v := range queryWeights {
-		types = append(types, k)
-		weights = append(weights, v)
-	}
-	return datagen.ChooseWeighted(e.faker, types, weights)
+// SelectQuery returns the name of a query chosen from the query mix.
+func (e *QueryExecutor) SelectQuery() string {
+	return e.mix.Choose(e.faker)
 }
 
 // Semantic Search - Vector similarity search for documents
@@ -11,8 +11,8 @@ package ecommerce
 
 import (
 	"context"
+	"sync"
 
-	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/pgEdge/pgedge-loadgen/internal/apps"
@@ -21,9 +21,19 @@ import (
 
 // App implements the e-commerce application with semantic search.
 type App struct {
-	executor   *QueryExecutor
-	embedder   embeddings.Embedder
-	dimensions int
+	mu           sync.Mutex
+	counts       *tableCounts // looked up by the first query executor
+	embedder     embeddings.Embedder
+	dimensions   int
+	queryWeights map[string]int
+}
+
+// tableCounts holds the table row counts the queries are parameterized with.
+type tableCounts struct {
+	products   int
+	customers  int
+	categories int
+	orders     int
 }
 
 // New creates a new ecommerce application.
@@ -78,7 +88,7 @@ func (a *App) GenerateData(ctx context.Context, pool *pgxpool.Pool, cfg apps.Gen
 	a.dimensions = embCfg.Dimensions
 	a.embedder = embeddings.NewEmbedder(embCfg)
 
-	gen := NewGenerator(a.embedder, a.dimensions)
+	gen := NewGenerator(a.embedder, a.dimensions, cfg.Seed)
 	return gen.GenerateData(ctx, pool, cfg.TargetSize)
 }
 
@@ -136,32 +146,34 @@ func (a *App) GetQueries() []apps.QueryDefinition {
 	}
 }
 
-// ExecuteQuery executes a randomly selected query based on the query mix.
-func (a *App) ExecuteQuery(ctx context.Context, pool *pgxpool.Pool) apps.QueryResult {
-	// Initialize executor if needed
-	if a.executor == nil {
-		// Ensure embedder is set
-		if a.embedder == nil {
-			a.embedder = embeddings.NewRandomEmbedder(a.dimensions)
-		}
-		numProducts, numCustomers, numCategories, numOrders := a.getTableCounts(ctx, pool)
-		a.executor = NewQueryExecutor(a.embedder, numProducts, numCustomers, numCategories, numOrders)
+// GetTables returns the tables in the application's schema.
+func (a *App) GetTables() []apps.TableDefinition {
+	return apps.TablesFromSizes(tableSizes)
+}
+
+// SetQueryWeights overrides the relative weights of the named queries.
+func (a *App) SetQueryWeights(weights map[string]int) {
+	a.queryWeights = weights
+}
+
+// NewQueryExecutor creates a query executor for a single worker.
+func (a *App) NewQueryExecutor(ctx context.Context, db apps.DB, seed uint64) apps.QueryExecutor {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Look up the table counts once and share them between workers
+	if a.counts == nil {
+		a.counts = getTableCounts(ctx, db)
 	}
-	return a.executor.ExecuteRandomQuery(ctx, pool)
-}
-
-// ExecuteQueryConn executes a randomly selected query using a single connection.
-func (a *App) ExecuteQueryConn(ctx context.Context, conn *pgx.Conn) apps.QueryResult {
-	// Initialize executor if needed
-	if a.executor == nil {
-		// Ensure embedder is set
-		if a.embedder == nil {
-			a.embedder = embeddings.NewRandomEmbedder(a.dimensions)
-		}
-		numProducts, numCustomers, numCategories, numOrders := a.getTableCountsConn(ctx, conn)
-		a.executor = NewQueryExecutor(a.embedder, numProducts, numCustomers, numCategories, numOrders)
+
+	// Initialize embedder with default if not set
+	if a.embedder == nil {
+		a.embedder = embeddings.NewRandomEmbedder(a.dimensions)
 	}
-	return a.executor.ExecuteRandomQuery(ctx, conn)
+
+	c := a.counts
+	return NewQueryExecutor(apps.NewQueryMix(a.GetQueries(), a.queryWeights), seed,
+		a.embedder, c.products, c.customers, c.categories, c.orders)
 }
 
 // RequiresPgvector returns true if the app needs pgvector extension.
@@ -169,28 +181,22 @@ func (a *App) RequiresPgvector() bool {
 	return true
 }
 
-func (a *App) getTableCounts(ctx context.Context, pool *pgxpool.Pool) (int, int, int, int) {
+func getTableCounts(ctx context.Context, db apps.DB) *tableCounts {
 	var numProducts, numCustomers, numCategories, numOrders int
 
-	_ = pool.QueryRow(ctx, "SELECT COUNT(*) FROM product").Scan(&numProducts)
-	_ = pool.QueryRow(ctx, "SELECT COUNT(*) FROM customer").Scan(&numCustomers)
-	_ = pool.QueryRow(ctx, "SELECT COUNT(*) FROM category").Scan(&numCategories)
-	_ = pool.QueryRow(ctx, "SELECT COUNT(*) FROM orders").Scan(&numOrders)
-
-	return max(1, numProducts), max(1, numCustomers), max(1, numCategories), max(1, numOrders)
-}
+	_ = db.QueryRow(ctx, "SELECT COUNT(*) FROM product").Scan(&numProducts)
+	_ = db.QueryRow(ctx, "SELECT COUNT(*) FROM customer").Scan(&numCustomers)
+	_ = db.QueryRow(ctx, "SELECT COUNT(*) FROM category").Scan(&numCategories)
+	_ = db.QueryRow(ctx, "SELECT COUNT(*) FROM orders").Scan(&numOrders)
 
-func (a *App) getTableCountsConn(ctx context.Context, conn *pgx.Conn) (int, int, int, int) {
-	var numProducts, numCustomers, numCategories, numOrders int
-
-	_ = conn.QueryRow(ctx, "SELECT COUNT(*) FROM product").Scan(&numProducts)
-	_ = conn.QueryRow(ctx, "SELECT COUNT(*) FROM customer").Scan(&numCustomers)
-	_ = conn.QueryRow(ctx, "SELECT COUNT(*) FROM category").Scan(&numCategories)
-	_ = conn.QueryRow(ctx, "SELECT COUNT(*) FROM orders").Scan(&numOrders)
-
-	return max(1, numProducts), max(1, numCustomers), max(1, numCategories), max(1, numOrders)
+	return &tableCounts{
+		products:   max(1, numProducts),
+		customers:  max(1, numCustomers),
+		categories: max(1, numCategories),
+		orders:     max(1, numOrders),
+	}
 }
 
 func init() {
-	apps.Register(New())
+	apps.Register("ecommerce", func() apps.App { return New() })
 }
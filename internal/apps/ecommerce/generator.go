@@ -58,9 +58,9 @@ type Generator struct {
 }
 
 // NewGenerator creates a new ecommerce data generator.
-func NewGenerator(embedder embeddings.Embedder, dimensions int) *Generator {
+func NewGenerator(embedder embeddings.Embedder, dimensions int, seed uint64) *Generator {
 	return &Generator{
-		faker:      datagen.NewFaker(),
+		faker:      datagen.NewFakerWithSeed(seed),
 		cfg:        datagen.DefaultBatchConfig(),
 		embedder:   embedder,
 		dimensions: dimensions,
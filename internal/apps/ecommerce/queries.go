@@ -11,6 +11,7 @@ package ecommerce
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/pgEdge/pgedge-loadgen/internal/apps"
@@ -18,21 +19,10 @@ import (
 	"github.com/pgEdge/pgedge-loadgen/internal/datagen/embeddings"
 )
 
-// Query weights for e-commerce workload
-var queryWeights = map[string]int{
-	"semantic_search":  30,
-	"category_browse":  20,
-	"similar_products": 15,
-	"add_to_cart":      10,
-	"checkout":         5,
-	"submit_review":    5,
-	"order_history":    10,
-	"inventory_check":  5,
-}
-
 // QueryExecutor executes ecommerce queries.
 type QueryExecutor struct {
 	faker         *datagen.Faker
+	mix           *apps.QueryMix
 	embedder      embeddings.Embedder
 	numProducts   int
 	numCustomers  int
@@ -41,9 +31,10 @@ type QueryExecutor struct {
 }
 
 // NewQueryExecutor creates a new query executor.
-func NewQueryExecutor(embedder embeddings.Embedder, numProducts, numCustomers, numCategories, numOrders int) *QueryExecutor {
+func NewQueryExecutor(mix *apps.QueryMix, seed uint64, embedder embeddings.Embedder, numProducts, numCustomers, numCategories, numOrders int) *QueryExecutor {
 	return &QueryExecutor{
-		faker:         datagen.NewFaker(),
+		faker:         datagen.NewFakerWithSeed(seed),
+		mix:           mix,
 		embedder:      embedder,
 		numProducts:   max(1, numProducts),
 		numCustomers:  max(1, numCustomers),
@@ -54,8 +45,11 @@ func NewQueryExecutor(embedder embeddings.Embedder, numProducts, numCustomers, n
 
 // ExecuteRandomQuery executes a random query based on weights.
 func (e *QueryExecutor) ExecuteRandomQuery(ctx context.Context, db apps.DB) apps.QueryResult {
-	queryType := e.selectQueryType()
+	return e.ExecuteQuery(ctx, db, e.SelectQuery())
+}
 
+// ExecuteQuery executes the named query, e.g. to retry a failed query.
+func (e *QueryExecutor) ExecuteQuery(ctx context.Context, db apps.DB, queryType string) apps.QueryResult {
 	start := time.Now()
 	var err error
 	var rowsAffected int64
@@ -77,6 +71,8 @@ func (e *QueryExecutor) ExecuteRandomQuery(ctx context.Context, db apps.DB) apps
 		rowsAffected, err = e.executeOrderHistory(ctx, db)
 	case "inventory_check":
 		rowsAffected, err = e.executeInventoryCheck(ctx, db)
+	default:
+		err = fmt.Errorf("unknown query '%s'", queryType)
 	}
 
 	return apps.QueryResult{
@@ -87,14 +83,9 @@ func (e *QueryExecutor) ExecuteRandomQuery(ctx context.Context, db apps.DB) apps
 	}
 }
 
-func (e *QueryExecutor) selectQueryType() string {
-	types := make([]string, 0, len(queryWeights))
-	weights := make([]int, 0, len(queryWeights))
-	for k, v := range queryWeights {
-		types = append(types, k)
-		weights = append(weights, v)
-	}
-	return datagen.ChooseWeighted(e.faker, types, weights)
+// SelectQuery returns the name of a query chosen from the query mix.
+func (e *QueryExecutor) SelectQuery() string {
+	return e.mix.Choose(e.faker)
 }
 
 // Semantic Search - Vector similarity search for products
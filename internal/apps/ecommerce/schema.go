@@ -20,8 +20,8 @@ import (
 // Schema SQL template for creating the ecommerce database schema.
 // Uses %d for embedding dimensions which will be formatted.
 const createSchemaSQLTemplate = `
--- Enable pgvector extension
-CREATE EXTENSION IF NOT EXISTS vector;
+-- Enable pgvector extension (in public, so it is shared by all schemas)
+CREATE EXTENSION IF NOT EXISTS vector SCHEMA public;
 
 -- Category: Product categories
 CREATE TABLE IF NOT EXISTS category (
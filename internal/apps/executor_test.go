@@ -0,0 +1,129 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package apps_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/pgEdge/pgedge-loadgen/internal/apps"
+)
+
+var errNoDatabase = errors.New("no database")
+
+// failingDB is an apps.DB on which every statement fails.
+type failingDB struct{}
+
+type failingRow struct{}
+
+func (failingRow) Scan(dest ...any) error { return errNoDatabase }
+
+func (failingDB) Begin(ctx context.Context) (pgx.Tx, error) { return nil, errNoDatabase }
+
+func (failingDB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, errNoDatabase
+}
+
+func (failingDB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return nil, errNoDatabase
+}
+
+func (failingDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return failingRow{}
+}
+
+// queryNames returns the names of the next n queries run by an executor.
+func queryNames(ctx context.Context, e apps.QueryExecutor, n int) []string {
+	names := make([]string, n)
+	for i := range names {
+		names[i] = e.ExecuteRandomQuery(ctx, failingDB{}).QueryName
+	}
+	return names
+}
+
+func TestQueryExecutorSeed(t *testing.T) {
+	ctx := context.Background()
+
+	for _, app := range apps.All() {
+		t.Run(app.Name(), func(t *testing.T) {
+			a := queryNames(ctx, app.NewQueryExecutor(ctx, failingDB{}, 42), 50)
+			b := queryNames(ctx, app.NewQueryExecutor(ctx, failingDB{}, 42), 50)
+			for i := range a {
+				if a[i] != b[i] {
+					t.Fatalf("Executors with the same seed diverged at query %d: %s != %s", i, a[i], b[i])
+				}
+			}
+		})
+	}
+}
+
+func TestQueryExecutorSelectQuery(t *testing.T) {
+	ctx := context.Background()
+
+	for _, app := range apps.All() {
+		t.Run(app.Name(), func(t *testing.T) {
+			want := queryNames(ctx, app.NewQueryExecutor(ctx, failingDB{}, 42), 50)
+			e := app.NewQueryExecutor(ctx, failingDB{}, 42)
+			for i := range want {
+				name := e.SelectQuery()
+				if name != want[i] {
+					t.Fatalf("Query %d: expected %s, got %s", i, want[i], name)
+				}
+				e.ExecuteQuery(ctx, failingDB{}, name)
+			}
+		})
+	}
+}
+
+func TestQueryExecutorExecuteQuery(t *testing.T) {
+	ctx := context.Background()
+
+	for _, app := range apps.All() {
+		t.Run(app.Name(), func(t *testing.T) {
+			e := app.NewQueryExecutor(ctx, failingDB{}, 42)
+			for _, q := range app.GetQueries() {
+				result := e.ExecuteQuery(ctx, failingDB{}, q.Name)
+				if result.QueryName != q.Name {
+					t.Errorf("Expected query '%s', got '%s'", q.Name, result.QueryName)
+				}
+			}
+
+			result := e.ExecuteQuery(ctx, failingDB{}, "no_such_query")
+			if result.Error == nil || errors.Is(result.Error, errNoDatabase) {
+				t.Errorf("Expected an unknown query error, got %v", result.Error)
+			}
+		})
+	}
+}
+
+func TestQueryExecutorConcurrent(t *testing.T) {
+	ctx := context.Background()
+
+	// Executors are created and used concurrently by the workers; run with
+	// -race to check that they share no mutable state.
+	for _, app := range apps.All() {
+		t.Run(app.Name(), func(t *testing.T) {
+			var wg sync.WaitGroup
+			for i := 0; i < 4; i++ {
+				wg.Add(1)
+				go func(seed uint64) {
+					defer wg.Done()
+					queryNames(ctx, app.NewQueryExecutor(ctx, failingDB{}, seed), 20)
+				}(uint64(i))
+			}
+			wg.Wait()
+		})
+	}
+}
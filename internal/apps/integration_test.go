@@ -125,9 +125,10 @@ func runAppIntegrationTest(t *testing.T, appName string, requiresVector bool) {
 		// Run queries for a short period
 		queryCount := 50
 		errorCount := 0
+		queries := app.NewQueryExecutor(ctx, pool, 1)
 
 		for i := 0; i < queryCount; i++ {
-			result := app.ExecuteQuery(ctx, pool)
+			result := queries.ExecuteRandomQuery(ctx, pool)
 			if result.Error != nil {
 				errorCount++
 				t.Logf("Query %s failed: %v", result.QueryName, result.Error)
@@ -145,7 +146,7 @@ func runAppIntegrationTest(t *testing.T, appName string, requiresVector bool) {
 	// Test 4: Verify data exists by running queries that should return rows
 	t.Run("VerifyData", func(t *testing.T) {
 		// Verify at least one table has data by executing a query
-		result := app.ExecuteQuery(ctx, pool)
+		result := app.NewQueryExecutor(ctx, pool, 2).ExecuteRandomQuery(ctx, pool)
 		if result.Error != nil {
 			t.Logf("Warning: Query returned error: %v", result.Error)
 		}
@@ -224,9 +225,11 @@ func TestConcurrentQueries(t *testing.T) {
 	done := make(chan struct{})
 
 	for i := 0; i < concurrency; i++ {
+		// Each worker uses its own query executor
+		queries := app.NewQueryExecutor(ctx, pool, uint64(i))
 		go func() {
 			for j := 0; j < queriesPerWorker; j++ {
-				result := app.ExecuteQuery(ctx, pool)
+				result := queries.ExecuteRandomQuery(ctx, pool)
 				if result.Error != nil {
 					errChan <- result.Error
 				}
@@ -291,9 +294,10 @@ func TestQueryLatency(t *testing.T) {
 	// Measure query latencies
 	queryCount := 50
 	var totalDuration int64
+	queries := app.NewQueryExecutor(ctx, pool, 1)
 
 	for i := 0; i < queryCount; i++ {
-		result := app.ExecuteQuery(ctx, pool)
+		result := queries.ExecuteRandomQuery(ctx, pool)
 		if result.Error == nil {
 			totalDuration += result.Duration
 		}
@@ -369,12 +373,14 @@ func TestContextCancellation(t *testing.T) {
 		t.Fatalf("GenerateData failed: %v", err)
 	}
 
+	queries := app.NewQueryExecutor(ctx, pool, 1)
+
 	// Create cancelled context
 	cancelledCtx, cancel := context.WithCancel(context.Background())
 	cancel() // Cancel immediately
 
 	// Query should handle cancelled context gracefully
-	result := app.ExecuteQuery(cancelledCtx, pool)
+	result := queries.ExecuteRandomQuery(cancelledCtx, pool)
 	// Error is expected but should not panic
 	_ = result
 
@@ -383,7 +389,7 @@ func TestContextCancellation(t *testing.T) {
 	defer cancel2()
 	time.Sleep(1 * time.Millisecond) // Ensure timeout
 
-	result2 := app.ExecuteQuery(timeoutCtx, pool)
+	result2 := queries.ExecuteRandomQuery(timeoutCtx, pool)
 	_ = result2
 	// Should not panic
 }
@@ -11,8 +11,8 @@ package knowledgebase
 
 import (
 	"context"
+	"sync"
 
-	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/pgEdge/pgedge-loadgen/internal/apps"
@@ -21,8 +21,18 @@ import (
 
 // App implements the knowledge base application with semantic search.
 type App struct {
-	executor *QueryExecutor
-	embedder embeddings.Embedder
+	mu           sync.Mutex
+	counts       *tableCounts // looked up by the first query executor
+	embedder     embeddings.Embedder
+	queryWeights map[string]int
+}
+
+// tableCounts holds the table row counts the queries are parameterized with.
+type tableCounts struct {
+	articles   int
+	users      int
+	categories int
+	searches   int
 }
 
 // New creates a new knowledge base application.
@@ -70,7 +80,7 @@ func (a *App) GenerateData(ctx context.Context, pool *pgxpool.Pool, cfg apps.Gen
 	})
 	a.embedder = embedder
 
-	gen := NewGenerator(embedder, cfg.EmbeddingDimensions)
+	gen := NewGenerator(embedder, cfg.EmbeddingDimensions, cfg.Seed)
 	return gen.GenerateData(ctx, pool, cfg.TargetSize)
 }
 
@@ -116,42 +126,37 @@ func (a *App) GetQueries() []apps.QueryDefinition {
 	}
 }
 
-// ExecuteQuery executes a randomly selected query based on the query mix.
-func (a *App) ExecuteQuery(ctx context.Context, pool *pgxpool.Pool) apps.QueryResult {
-	// Initialize executor if needed (lazy initialization to get counts)
-	if a.executor == nil {
-		numArticles, numUsers, numCategories, numSearches := a.getTableCounts(ctx, pool)
-
-		// Initialize embedder with default if not set
-		if a.embedder == nil {
-			a.embedder = embeddings.NewEmbedder(embeddings.Config{
-				Mode:       "random",
-				Dimensions: 384,
-			})
-		}
+// GetTables returns the tables in the application's schema.
+func (a *App) GetTables() []apps.TableDefinition {
+	return apps.TablesFromSizes(tableSizes)
+}
 
-		a.executor = NewQueryExecutor(a.embedder, numArticles, numUsers, numCategories, numSearches)
-	}
-	return a.executor.ExecuteRandomQuery(ctx, pool)
+// SetQueryWeights overrides the relative weights of the named queries.
+func (a *App) SetQueryWeights(weights map[string]int) {
+	a.queryWeights = weights
 }
 
-// ExecuteQueryConn executes a randomly selected query using a single connection.
-func (a *App) ExecuteQueryConn(ctx context.Context, conn *pgx.Conn) apps.QueryResult {
-	// Initialize executor if needed (lazy initialization to get counts)
-	if a.executor == nil {
-		numArticles, numUsers, numCategories, numSearches := a.getTableCountsConn(ctx, conn)
+// NewQueryExecutor creates a query executor for a single worker.
+func (a *App) NewQueryExecutor(ctx context.Context, db apps.DB, seed uint64) apps.QueryExecutor {
+	a.mu.Lock()
+	defer a.mu.Unlock()
 
-		// Initialize embedder with default if not set
-		if a.embedder == nil {
-			a.embedder = embeddings.NewEmbedder(embeddings.Config{
-				Mode:       "random",
-				Dimensions: 384,
-			})
-		}
+	// Look up the table counts once and share them between workers
+	if a.counts == nil {
+		a.counts = getTableCounts(ctx, db)
+	}
 
-		a.executor = NewQueryExecutor(a.embedder, numArticles, numUsers, numCategories, numSearches)
+	// Initialize embedder with default if not set
+	if a.embedder == nil {
+		a.embedder = embeddings.NewEmbedder(embeddings.Config{
+			Mode:       "random",
+			Dimensions: 384,
+		})
 	}
-	return a.executor.ExecuteRandomQuery(ctx, conn)
+
+	c := a.counts
+	return NewQueryExecutor(apps.NewQueryMix(a.GetQueries(), a.queryWeights), seed,
+		a.embedder, c.articles, c.users, c.categories, c.searches)
 }
 
 // RequiresPgvector returns true if the app needs pgvector extension.
@@ -159,28 +164,22 @@ func (a *App) RequiresPgvector() bool {
 	return true
 }
 
-func (a *App) getTableCounts(ctx context.Context, pool *pgxpool.Pool) (int, int, int, int) {
+func getTableCounts(ctx context.Context, db apps.DB) *tableCounts {
 	var numArticles, numUsers, numCategories, numSearches int
 
-	_ = pool.QueryRow(ctx, "SELECT COUNT(*) FROM article").Scan(&numArticles)
-	_ = pool.QueryRow(ctx, "SELECT COUNT(*) FROM kb_user").Scan(&numUsers)
-	_ = pool.QueryRow(ctx, "SELECT COUNT(*) FROM category").Scan(&numCategories)
-	_ = pool.QueryRow(ctx, "SELECT COUNT(*) FROM search_log").Scan(&numSearches)
+	_ = db.QueryRow(ctx, "SELECT COUNT(*) FROM article").Scan(&numArticles)
+	_ = db.QueryRow(ctx, "SELECT COUNT(*) FROM kb_user").Scan(&numUsers)
+	_ = db.QueryRow(ctx, "SELECT COUNT(*) FROM category").Scan(&numCategories)
+	_ = db.QueryRow(ctx, "SELECT COUNT(*) FROM search_log").Scan(&numSearches)
 
-	return max(1, numArticles), max(1, numUsers), max(1, numCategories), max(1, numSearches)
-}
-
-func (a *App) getTableCountsConn(ctx context.Context, conn *pgx.Conn) (int, int, int, int) {
-	var numArticles, numUsers, numCategories, numSearches int
-
-	_ = conn.QueryRow(ctx, "SELECT COUNT(*) FROM article").Scan(&numArticles)
-	_ = conn.QueryRow(ctx, "SELECT COUNT(*) FROM kb_user").Scan(&numUsers)
-	_ = conn.QueryRow(ctx, "SELECT COUNT(*) FROM category").Scan(&numCategories)
-	_ = conn.QueryRow(ctx, "SELECT COUNT(*) FROM search_log").Scan(&numSearches)
-
-	return max(1, numArticles), max(1, numUsers), max(1, numCategories), max(1, numSearches)
+	return &tableCounts{
+		articles:   max(1, numArticles),
+		users:      max(1, numUsers),
+		categories: max(1, numCategories),
+		searches:   max(1, numSearches),
+	}
 }
 
 func init() {
-	apps.Register(New())
+	apps.Register("knowledgebase", func() apps.App { return New() })
 }
@@ -43,9 +43,9 @@ type Generator struct {
 }
 
 // NewGenerator creates a new data generator.
-func NewGenerator(embedder embeddings.Embedder, dimensions int) *Generator {
+func NewGenerator(embedder embeddings.Embedder, dimensions int, seed uint64) *Generator {
 	return &Generator{
-		faker:      datagen.NewFaker(),
+		faker:      datagen.NewFakerWithSeed(seed),
 		cfg:        datagen.DefaultBatchConfig(),
 		embedder:   embedder,
 		dimensions: dimensions,
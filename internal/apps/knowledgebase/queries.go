@@ -11,6 +11,7 @@ package knowledgebase
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/pgEdge/pgedge-loadgen/internal/apps"
@@ -18,19 +19,10 @@ import (
 	"github.com/pgEdge/pgedge-loadgen/internal/datagen/embeddings"
 )
 
-// Query weights for knowledge base workload
-var queryWeights = map[string]int{
-	"semantic_search":   40,
-	"similar_questions": 20,
-	"browse_category":   15,
-	"view_article":      10,
-	"submit_feedback":   10,
-	"admin_update":      5,
-}
-
 // QueryExecutor executes knowledgebase queries.
 type QueryExecutor struct {
 	faker         *datagen.Faker
+	mix           *apps.QueryMix
 	embedder      embeddings.Embedder
 	numArticles   int
 	numUsers      int
@@ -39,9 +31,10 @@ type QueryExecutor struct {
 }
 
 // NewQueryExecutor creates a new query executor.
-func NewQueryExecutor(embedder embeddings.Embedder, numArticles, numUsers, numCategories, numSearches int) *QueryExecutor {
+func NewQueryExecutor(mix *apps.QueryMix, seed uint64, embedder embeddings.Embedder, numArticles, numUsers, numCategories, numSearches int) *QueryExecutor {
 	return &QueryExecutor{
-		faker:         datagen.NewFaker(),
+		faker:         datagen.NewFakerWithSeed(seed),
+		mix:           mix,
 		embedder:      embedder,
 		numArticles:   max(1, numArticles),
 		numUsers:      max(1, numUsers),
@@ -52,8 +45,11 @@ func NewQueryExecutor(embedder embeddings.Embedder, numArticles, numUsers, numCa
 
 // ExecuteRandomQuery executes a random query based on weights.
 func (e *QueryExecutor) ExecuteRandomQuery(ctx context.Context, db apps.DB) apps.QueryResult {
-	queryType := e.selectQueryType()
+	return e.ExecuteQuery(ctx, db, e.SelectQuery())
+}
 
+// ExecuteQuery executes the named query, e.g. to retry a failed query.
+func (e *QueryExecutor) ExecuteQuery(ctx context.Context, db apps.DB, queryType string) apps.QueryResult {
 	start := time.Now()
 	var err error
 	var rowsAffected int64
@@ -71,6 +67,8 @@ func (e *QueryExecutor) ExecuteRandomQuery(ctx context.Context, db apps.DB) apps
 		rowsAffected, err = e.executeSubmitFeedback(ctx, db)
 	case "admin_update":
 		rowsAffected, err = e.executeAdminUpdate(ctx, db)
+	default:
+		err = fmt.Errorf("unknown query '%s'", queryType)
 	}
 
 	return apps.QueryResult{
@@ -81,14 +79,9 @@ func (e *QueryExecutor) ExecuteRandomQuery(ctx context.Context, db apps.DB) apps
 	}
 }
 
-func (e *QueryExecutor) selectQueryType() string {
-	types := make([]string, 0, len(queryWeights))
-	weights := make([]int, 0, len(queryWeights))
-	for k, v := range queryWeights {
-		types = append(types, k)
-		weights = append(weights, v)
-	}
-	return datagen.ChooseWeighted(e.faker, types, weights)
+// SelectQuery returns the name of a query chosen from the query mix.
+func (e *QueryExecutor) SelectQuery() string {
+	return e.mix.Choose(e.faker)
 }
 
 // Semantic Search - Vector similarity search for articles
@@ -19,8 +19,8 @@ import (
 
 // Schema SQL template for creating the knowledgebase database schema.
 const createSchemaSQLTemplate = `
--- Enable pgvector extension
-CREATE EXTENSION IF NOT EXISTS vector;
+-- Enable pgvector extension (in public, so it is shared by all schemas)
+CREATE EXTENSION IF NOT EXISTS vector SCHEMA public;
 
 -- Category: Article categories
 CREATE TABLE IF NOT EXISTS category (
@@ -0,0 +1,101 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package apps
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pgEdge/pgedge-loadgen/internal/datagen"
+)
+
+// QueryMix selects queries at random according to their relative weights.
+type QueryMix struct {
+	names   []string
+	weights []int
+}
+
+// NewQueryMix creates a query mix from an application's query definitions,
+// with the weights of the named queries replaced by the given overrides.
+func NewQueryMix(queries []QueryDefinition, overrides map[string]int) *QueryMix {
+	m := &QueryMix{}
+	for _, q := range queries {
+		weight := q.Weight
+		if w, ok := overrides[q.Name]; ok {
+			weight = w
+		}
+		if weight > 0 {
+			m.names = append(m.names, q.Name)
+			m.weights = append(m.weights, weight)
+		}
+	}
+	return m
+}
+
+// Choose returns the name of a randomly selected query.
+func (m *QueryMix) Choose(f *datagen.Faker) string {
+	return datagen.ChooseWeighted(f, m.names, m.weights)
+}
+
+// ValidateQueryWeights checks that weight overrides refer to queries of the
+// application, are non-negative and leave at least one query enabled.
+func ValidateQueryWeights(app App, overrides map[string]int) error {
+	if err := checkQueryNames(app, overrides); err != nil {
+		return err
+	}
+	for name, weight := range overrides {
+		if weight < 0 {
+			return fmt.Errorf("weight for query '%s' must be non-negative", name)
+		}
+	}
+
+	if len(NewQueryMix(app.GetQueries(), overrides).names) == 0 {
+		return fmt.Errorf("query weights disable all queries for app '%s'", app.Name())
+	}
+
+	return nil
+}
+
+// ValidateQueryTimeouts checks that per-query timeouts refer to queries of
+// the application and are non-negative.
+func ValidateQueryTimeouts(app App, timeouts map[string]int) error {
+	if err := checkQueryNames(app, timeouts); err != nil {
+		return err
+	}
+	for name, timeout := range timeouts {
+		if timeout < 0 {
+			return fmt.Errorf("timeout for query '%s' must be non-negative", name)
+		}
+	}
+	return nil
+}
+
+// checkQueryNames checks that the keys of a per-query setting are the
+// names of queries of the application.
+func checkQueryNames(app App, settings map[string]int) error {
+	queries := app.GetQueries()
+
+	known := make(map[string]bool, len(queries))
+	names := make([]string, 0, len(queries))
+	for _, q := range queries {
+		known[q.Name] = true
+		names = append(names, q.Name)
+	}
+	sort.Strings(names)
+
+	for name := range settings {
+		if !known[name] {
+			return fmt.Errorf("unknown query '%s' for app '%s' (valid queries: %s)",
+				name, app.Name(), strings.Join(names, ", "))
+		}
+	}
+	return nil
+}
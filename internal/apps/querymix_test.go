@@ -0,0 +1,128 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package apps_test
+
+import (
+	"testing"
+
+	"github.com/pgEdge/pgedge-loadgen/internal/apps"
+	"github.com/pgEdge/pgedge-loadgen/internal/datagen"
+)
+
+func TestQueryMix(t *testing.T) {
+	queries := []apps.QueryDefinition{
+		{Name: "read", Weight: 90},
+		{Name: "write", Weight: 10},
+		{Name: "disabled", Weight: 0},
+	}
+
+	tests := []struct {
+		name      string
+		overrides map[string]int
+		allowed   map[string]bool
+	}{
+		{"defaults", nil, map[string]bool{"read": true, "write": true}},
+		{"read only", map[string]int{"write": 0}, map[string]bool{"read": true}},
+		{"enable disabled", map[string]int{"read": 0, "write": 0, "disabled": 1}, map[string]bool{"disabled": true}},
+	}
+
+	faker := datagen.NewFaker()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mix := apps.NewQueryMix(queries, tt.overrides)
+			seen := make(map[string]bool)
+			for i := 0; i < 1000; i++ {
+				name := mix.Choose(faker)
+				if !tt.allowed[name] {
+					t.Fatalf("Unexpected query %q chosen", name)
+				}
+				seen[name] = true
+			}
+			if len(seen) != len(tt.allowed) {
+				t.Errorf("Expected all of %v to be chosen, got %v", tt.allowed, seen)
+			}
+		})
+	}
+}
+
+func TestValidateQueryWeights(t *testing.T) {
+	app, err := apps.Get("wholesale")
+	if err != nil {
+		t.Fatalf("Failed to get app: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		weights   map[string]int
+		wantError bool
+	}{
+		{"empty", nil, false},
+		{"valid override", map[string]int{"new_order": 10, "delivery": 0}, false},
+		{"unknown query", map[string]int{"no_such_query": 10}, true},
+		{"negative weight", map[string]int{"payment": -1}, true},
+		{"all disabled", map[string]int{
+			"new_order": 0, "payment": 0, "order_status": 0, "delivery": 0, "stock_level": 0,
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := apps.ValidateQueryWeights(app, tt.weights)
+			if tt.wantError && err == nil {
+				t.Error("Expected error, got nil")
+			}
+			if !tt.wantError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateQueryTimeouts(t *testing.T) {
+	app, err := apps.Get("wholesale")
+	if err != nil {
+		t.Fatalf("Failed to get app: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		timeouts  map[string]int
+		wantError bool
+	}{
+		{"empty", nil, false},
+		{"valid timeouts", map[string]int{"delivery": 500, "stock_level": 0}, false},
+		{"unknown query", map[string]int{"no_such_query": 500}, true},
+		{"negative timeout", map[string]int{"payment": -1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := apps.ValidateQueryTimeouts(app, tt.timeouts)
+			if tt.wantError && err == nil {
+				t.Error("Expected error, got nil")
+			}
+			if !tt.wantError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestQueryWeightsEnableDisabledQuery(t *testing.T) {
+	app, err := apps.Get("analytics")
+	if err != nil {
+		t.Fatalf("Failed to get app: %v", err)
+	}
+
+	// pricing_summary is disabled by default but can be re-enabled
+	if err := apps.ValidateQueryWeights(app, map[string]int{"pricing_summary": 5}); err != nil {
+		t.Errorf("Unexpected error enabling pricing_summary: %v", err)
+	}
+}
@@ -11,34 +11,40 @@ package apps
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 )
 
+// Factory creates an instance of an application.
+type Factory func() App
+
 var (
-	registry = make(map[string]App)
+	registry = make(map[string]Factory)
 	mu       sync.RWMutex
 )
 
 // Register adds an application to the registry.
-func Register(app App) {
+func Register(name string, factory Factory) {
 	mu.Lock()
 	defer mu.Unlock()
-	registry[app.Name()] = app
+	registry[name] = factory
 }
 
-// Get retrieves an application by name.
+// Get creates an instance of an application by name. Each instance has its
+// own state, such as query weights and row counts looked up from the
+// database, so that several workloads of the same app can run at once.
 func Get(name string) (App, error) {
 	mu.RLock()
 	defer mu.RUnlock()
 
-	app, ok := registry[name]
+	factory, ok := registry[name]
 	if !ok {
 		return nil, fmt.Errorf("unknown application: %s", name)
 	}
-	return app, nil
+	return factory(), nil
 }
 
-// List returns all registered application names.
+// List returns all registered application names, sorted by name.
 func List() []string {
 	mu.RLock()
 	defer mu.RUnlock()
@@ -47,17 +53,21 @@ func List() []string {
 	for name := range registry {
 		names = append(names, name)
 	}
+	sort.Strings(names)
 	return names
 }
 
-// All returns all registered applications.
+// All returns an instance of each registered application, sorted by name.
 func All() []App {
 	mu.RLock()
 	defer mu.RUnlock()
 
 	apps := make([]App, 0, len(registry))
-	for _, app := range registry {
-		apps = append(apps, app)
+	for _, factory := range registry {
+		apps = append(apps, factory())
 	}
+	sort.Slice(apps, func(i, j int) bool {
+		return apps[i].Name() < apps[j].Name()
+	})
 	return apps
 }
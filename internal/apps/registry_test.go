@@ -10,6 +10,7 @@
 package apps_test
 
 import (
+	"sort"
 	"testing"
 
 	"github.com/pgEdge/pgedge-loadgen/internal/apps"
@@ -55,6 +56,22 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestGetNewInstance(t *testing.T) {
+	// Each workload of an app gets its own instance, so that query weights
+	// set for one do not apply to another
+	app1, err := apps.Get("wholesale")
+	if err != nil {
+		t.Fatalf("Failed to get app: %v", err)
+	}
+	app2, err := apps.Get("wholesale")
+	if err != nil {
+		t.Fatalf("Failed to get app: %v", err)
+	}
+	if app1 == app2 {
+		t.Error("Expected a new instance from each Get")
+	}
+}
+
 func TestGetInvalidApp(t *testing.T) {
 	_, err := apps.Get("nonexistent")
 	if err == nil {
@@ -207,3 +224,20 @@ func BenchmarkList(b *testing.B) {
 		apps.List()
 	}
 }
+
+func TestListSorted(t *testing.T) {
+	appList := apps.List()
+	if !sort.StringsAreSorted(appList) {
+		t.Errorf("List() is not sorted: %v", appList)
+	}
+
+	all := apps.All()
+	if len(all) != len(appList) {
+		t.Fatalf("All() returned %d apps, List() returned %d", len(all), len(appList))
+	}
+	for i, app := range all {
+		if app.Name() != appList[i] {
+			t.Errorf("All()[%d] is '%s', expected '%s'", i, app.Name(), appList[i])
+		}
+	}
+}
@@ -11,8 +11,8 @@ package retail
 
 import (
 	"context"
+	"sync"
 
-	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/pgEdge/pgedge-loadgen/internal/apps"
@@ -20,7 +20,16 @@ import (
 
 // App implements the retail analytics application (TPC-DS based).
 type App struct {
-	executor *QueryExecutor
+	mu           sync.Mutex
+	counts       *tableCounts // looked up by the first query executor
+	queryWeights map[string]int
+}
+
+// tableCounts holds the table row counts the queries are parameterized with.
+type tableCounts struct {
+	items     int
+	customers int
+	stores    int
 }
 
 // New creates a new retail application.
@@ -56,7 +65,7 @@ func (a *App) DropSchema(ctx context.Context, pool *pgxpool.Pool) error {
 
 // GenerateData generates test data for the application.
 func (a *App) GenerateData(ctx context.Context, pool *pgxpool.Pool, cfg apps.GeneratorConfig) error {
-	gen := NewGenerator()
+	gen := NewGenerator(cfg.Seed)
 	return gen.GenerateData(ctx, pool, cfg.TargetSize)
 }
 
@@ -132,24 +141,29 @@ func (a *App) GetQueries() []apps.QueryDefinition {
 	}
 }
 
-// ExecuteQuery executes a randomly selected query based on the query mix.
-func (a *App) ExecuteQuery(ctx context.Context, pool *pgxpool.Pool) apps.QueryResult {
-	// Initialize executor if needed (lazy initialization to get counts)
-	if a.executor == nil {
-		numItems, numCustomers, numStores := a.getTableCounts(ctx, pool)
-		a.executor = NewQueryExecutor(numItems, numCustomers, numStores)
-	}
-	return a.executor.ExecuteRandomQuery(ctx, pool)
+// GetTables returns the tables in the application's schema.
+func (a *App) GetTables() []apps.TableDefinition {
+	return apps.TablesFromSizes(tableSizes)
 }
 
-// ExecuteQueryConn executes a randomly selected query using a single connection.
-func (a *App) ExecuteQueryConn(ctx context.Context, conn *pgx.Conn) apps.QueryResult {
-	// Initialize executor if needed (lazy initialization to get counts)
-	if a.executor == nil {
-		numItems, numCustomers, numStores := a.getTableCountsConn(ctx, conn)
-		a.executor = NewQueryExecutor(numItems, numCustomers, numStores)
+// SetQueryWeights overrides the relative weights of the named queries.
+func (a *App) SetQueryWeights(weights map[string]int) {
+	a.queryWeights = weights
+}
+
+// NewQueryExecutor creates a query executor for a single worker.
+func (a *App) NewQueryExecutor(ctx context.Context, db apps.DB, seed uint64) apps.QueryExecutor {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Look up the table counts once and share them between workers
+	if a.counts == nil {
+		a.counts = getTableCounts(ctx, db)
 	}
-	return a.executor.ExecuteRandomQuery(ctx, conn)
+
+	c := a.counts
+	return NewQueryExecutor(apps.NewQueryMix(a.GetQueries(), a.queryWeights), seed,
+		c.items, c.customers, c.stores)
 }
 
 // RequiresPgvector returns true if the app needs pgvector extension.
@@ -157,26 +171,20 @@ func (a *App) RequiresPgvector() bool {
 	return false
 }
 
-func (a *App) getTableCounts(ctx context.Context, pool *pgxpool.Pool) (int, int, int) {
+func getTableCounts(ctx context.Context, db apps.DB) *tableCounts {
 	var numItems, numCustomers, numStores int
 
-	_ = pool.QueryRow(ctx, "SELECT COUNT(*) FROM item").Scan(&numItems)
-	_ = pool.QueryRow(ctx, "SELECT COUNT(*) FROM customer").Scan(&numCustomers)
-	_ = pool.QueryRow(ctx, "SELECT COUNT(*) FROM store").Scan(&numStores)
+	_ = db.QueryRow(ctx, "SELECT COUNT(*) FROM item").Scan(&numItems)
+	_ = db.QueryRow(ctx, "SELECT COUNT(*) FROM customer").Scan(&numCustomers)
+	_ = db.QueryRow(ctx, "SELECT COUNT(*) FROM store").Scan(&numStores)
 
-	return max(1, numItems), max(1, numCustomers), max(1, numStores)
-}
-
-func (a *App) getTableCountsConn(ctx context.Context, conn *pgx.Conn) (int, int, int) {
-	var numItems, numCustomers, numStores int
-
-	_ = conn.QueryRow(ctx, "SELECT COUNT(*) FROM item").Scan(&numItems)
-	_ = conn.QueryRow(ctx, "SELECT COUNT(*) FROM customer").Scan(&numCustomers)
-	_ = conn.QueryRow(ctx, "SELECT COUNT(*) FROM store").Scan(&numStores)
-
-	return max(1, numItems), max(1, numCustomers), max(1, numStores)
+	return &tableCounts{
+		items:     max(1, numItems),
+		customers: max(1, numCustomers),
+		stores:    max(1, numStores),
+	}
 }
 
 func init() {
-	apps.Register(New())
+	apps.Register("retail", func() apps.App { return New() })
 }
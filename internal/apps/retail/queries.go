@@ -11,39 +11,27 @@ package retail
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/pgEdge/pgedge-loadgen/internal/apps"
 	"github.com/pgEdge/pgedge-loadgen/internal/datagen"
 )
 
-// Query weights - TPC-DS style decision support queries
-var queryWeights = map[string]int{
-	"store_sales_by_date":     15,
-	"store_sales_by_item":     12,
-	"store_sales_by_customer": 10,
-	"web_sales_analysis":      12,
-	"catalog_sales_analysis":  10,
-	"cross_channel_sales":     8,
-	"customer_demographics":   8,
-	"promotion_effect":        7,
-	"inventory_analysis":      6,
-	"store_comparison":        6,
-	"time_series_sales":       6,
-}
-
 // QueryExecutor executes retail queries.
 type QueryExecutor struct {
 	faker        *datagen.Faker
+	mix          *apps.QueryMix
 	numItems     int
 	numCustomers int
 	numStores    int
 }
 
 // NewQueryExecutor creates a new query executor.
-func NewQueryExecutor(numItems, numCustomers, numStores int) *QueryExecutor {
+func NewQueryExecutor(mix *apps.QueryMix, seed uint64, numItems, numCustomers, numStores int) *QueryExecutor {
 	return &QueryExecutor{
-		faker:        datagen.NewFaker(),
+		faker:        datagen.NewFakerWithSeed(seed),
+		mix:          mix,
 		numItems:     max(1, numItems),
 		numCustomers: max(1, numCustomers),
 		numStores:    max(1, numStores),
@@ -52,8 +40,11 @@ func NewQueryExecutor(numItems, numCustomers, numStores int) *QueryExecutor {
 
 // ExecuteRandomQuery executes a random analytical query based on weights.
 func (e *QueryExecutor) ExecuteRandomQuery(ctx context.Context, db apps.DB) apps.QueryResult {
-	queryType := e.selectQueryType()
+	return e.ExecuteQuery(ctx, db, e.SelectQuery())
+}
 
+// ExecuteQuery executes the named query, e.g. to retry a failed query.
+func (e *QueryExecutor) ExecuteQuery(ctx context.Context, db apps.DB, queryType string) apps.QueryResult {
 	start := time.Now()
 	var err error
 	var rowsAffected int64
@@ -81,6 +72,8 @@ func (e *QueryExecutor) ExecuteRandomQuery(ctx context.Context, db apps.DB) apps
 		rowsAffected, err = e.executeStoreComparison(ctx, db)
 	case "time_series_sales":
 		rowsAffected, err = e.executeTimeSeriesSales(ctx, db)
+	default:
+		err = fmt.Errorf("unknown query '%s'", queryType)
 	}
 
 	return apps.QueryResult{
@@ -91,14 +84,9 @@ func (e *QueryExecutor) ExecuteRandomQuery(ctx context.Context, db apps.DB) apps
 	}
 }
 
-func (e *QueryExecutor) selectQueryType() string {
-	types := make([]string, 0, len(queryWeights))
-	weights := make([]int, 0, len(queryWeights))
-	for k, v := range queryWeights {
-		types = append(types, k)
-		weights = append(weights, v)
-	}
-	return datagen.ChooseWeighted(e.faker, types, weights)
+// SelectQuery returns the name of a query chosen from the query mix.
+func (e *QueryExecutor) SelectQuery() string {
+	return e.mix.Choose(e.faker)
 }
 
 // Store Sales by Date - Aggregate store sales by date dimension
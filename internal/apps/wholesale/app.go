@@ -11,8 +11,8 @@ package wholesale
 
 import (
 	"context"
+	"sync"
 
-	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/pgEdge/pgedge-loadgen/internal/apps"
@@ -20,7 +20,9 @@ import (
 
 // App implements the wholesale supplier application (TPC-C based).
 type App struct {
-	executor *QueryExecutor
+	mu            sync.Mutex
+	numWarehouses int // looked up by the first query executor
+	queryWeights  map[string]int
 }
 
 // New creates a new wholesale application.
@@ -56,7 +58,7 @@ func (a *App) DropSchema(ctx context.Context, pool *pgxpool.Pool) error {
 
 // GenerateData generates test data for the application.
 func (a *App) GenerateData(ctx context.Context, pool *pgxpool.Pool, cfg apps.GeneratorConfig) error {
-	gen := NewGenerator()
+	gen := NewGenerator(cfg.Seed)
 	return gen.GenerateData(ctx, pool, cfg.TargetSize)
 }
 
@@ -96,24 +98,26 @@ func (a *App) GetQueries() []apps.QueryDefinition {
 	}
 }
 
-// ExecuteQuery executes a randomly selected query based on the query mix.
-func (a *App) ExecuteQuery(ctx context.Context, pool *pgxpool.Pool) apps.QueryResult {
-	// Initialize executor if needed (lazy initialization to get warehouse count)
-	if a.executor == nil {
-		numWarehouses := a.getWarehouseCount(ctx, pool)
-		a.executor = NewQueryExecutor(numWarehouses)
-	}
-	return a.executor.ExecuteRandomQuery(ctx, pool)
+// GetTables returns the tables in the application's schema.
+func (a *App) GetTables() []apps.TableDefinition {
+	return apps.TablesFromSizes(tableSizes)
+}
+
+// SetQueryWeights overrides the relative weights of the named queries.
+func (a *App) SetQueryWeights(weights map[string]int) {
+	a.queryWeights = weights
 }
 
-// ExecuteQueryConn executes a randomly selected query using a single connection.
-func (a *App) ExecuteQueryConn(ctx context.Context, conn *pgx.Conn) apps.QueryResult {
-	// Initialize executor if needed (lazy initialization to get warehouse count)
-	if a.executor == nil {
-		numWarehouses := a.getWarehouseCountConn(ctx, conn)
-		a.executor = NewQueryExecutor(numWarehouses)
+// NewQueryExecutor creates a query executor for a single worker.
+func (a *App) NewQueryExecutor(ctx context.Context, db apps.DB, seed uint64) apps.QueryExecutor {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Look up the warehouse count once and share it between workers
+	if a.numWarehouses == 0 {
+		a.numWarehouses = getWarehouseCount(ctx, db)
 	}
-	return a.executor.ExecuteRandomQuery(ctx, conn)
+	return NewQueryExecutor(apps.NewQueryMix(a.GetQueries(), a.queryWeights), seed, a.numWarehouses)
 }
 
 // RequiresPgvector returns true if the app needs pgvector extension.
@@ -121,18 +125,9 @@ func (a *App) RequiresPgvector() bool {
 	return false
 }
 
-func (a *App) getWarehouseCount(ctx context.Context, pool *pgxpool.Pool) int {
-	var count int
-	err := pool.QueryRow(ctx, "SELECT COUNT(*) FROM warehouse").Scan(&count)
-	if err != nil {
-		return 1
-	}
-	return max(1, count)
-}
-
-func (a *App) getWarehouseCountConn(ctx context.Context, conn *pgx.Conn) int {
+func getWarehouseCount(ctx context.Context, db apps.DB) int {
 	var count int
-	err := conn.QueryRow(ctx, "SELECT COUNT(*) FROM warehouse").Scan(&count)
+	err := db.QueryRow(ctx, "SELECT COUNT(*) FROM warehouse").Scan(&count)
 	if err != nil {
 		return 1
 	}
@@ -140,5 +135,5 @@ func (a *App) getWarehouseCountConn(ctx context.Context, conn *pgx.Conn) int {
 }
 
 func init() {
-	apps.Register(New())
+	apps.Register("wholesale", func() apps.App { return New() })
 }
@@ -42,9 +42,9 @@ type Generator struct {
 }
 
 // NewGenerator creates a new wholesale data generator.
-func NewGenerator() *Generator {
+func NewGenerator(seed uint64) *Generator {
 	return &Generator{
-		faker: datagen.NewFaker(),
+		faker: datagen.NewFakerWithSeed(seed),
 		cfg:   datagen.DefaultBatchConfig(),
 	}
 }
@@ -23,22 +23,26 @@ import (
 // QueryExecutor executes wholesale queries.
 type QueryExecutor struct {
 	faker         *datagen.Faker
+	mix           *apps.QueryMix
 	numWarehouses int
 }
 
 // NewQueryExecutor creates a new query executor.
-func NewQueryExecutor(numWarehouses int) *QueryExecutor {
+func NewQueryExecutor(mix *apps.QueryMix, seed uint64, numWarehouses int) *QueryExecutor {
 	return &QueryExecutor{
-		faker:         datagen.NewFaker(),
+		faker:         datagen.NewFakerWithSeed(seed),
+		mix:           mix,
 		numWarehouses: max(1, numWarehouses),
 	}
 }
 
 // ExecuteRandomQuery executes a random query based on the TPC-C weights.
 func (e *QueryExecutor) ExecuteRandomQuery(ctx context.Context, db apps.DB) apps.QueryResult {
-	// Select query type based on weights
-	queryType := e.selectQueryType()
+	return e.ExecuteQuery(ctx, db, e.SelectQuery())
+}
 
+// ExecuteQuery executes the named query, e.g. to retry a failed query.
+func (e *QueryExecutor) ExecuteQuery(ctx context.Context, db apps.DB, queryType string) apps.QueryResult {
 	start := time.Now()
 	var err error
 
@@ -53,6 +57,8 @@ func (e *QueryExecutor) ExecuteRandomQuery(ctx context.Context, db apps.DB) apps
 		err = e.executeDelivery(ctx, db)
 	case "stock_level":
 		err = e.executeStockLevel(ctx, db)
+	default:
+		err = fmt.Errorf("unknown query '%s'", queryType)
 	}
 
 	return apps.QueryResult{
@@ -62,10 +68,9 @@ func (e *QueryExecutor) ExecuteRandomQuery(ctx context.Context, db apps.DB) apps
 	}
 }
 
-func (e *QueryExecutor) selectQueryType() string {
-	types := []string{"new_order", "payment", "order_status", "delivery", "stock_level"}
-	weights := []int{45, 43, 4, 4, 4}
-	return datagen.ChooseWeighted(e.faker, types, weights)
+// SelectQuery returns the name of a query chosen from the query mix.
+func (e *QueryExecutor) SelectQuery() string {
+	return e.mix.Choose(e.faker)
 }
 
 // New Order transaction
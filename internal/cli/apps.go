@@ -0,0 +1,314 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pgEdge/pgedge-loadgen/internal/apps"
+	"github.com/pgEdge/pgedge-loadgen/internal/datagen"
+)
+
+var (
+	appsFormat         string
+	appsDescribeFormat string
+	appsDescribeSize   string
+)
+
+var appsCmd = &cobra.Command{
+	Use:   "apps",
+	Short: "List available applications",
+	Long: `List all available fictional applications that can be used for
+load generation. Each application has a unique schema and query mix.`,
+	Args: cobra.NoArgs,
+	RunE: runApps,
+}
+
+var appsDescribeCmd = &cobra.Command{
+	Use:   "describe <app>",
+	Short: "Show details of an application",
+	Long: `Show the details of an application: its workload type, query mix,
+schema tables with estimated row sizes and row counts for a target
+database size, and whether it requires pgvector or supports automatic
+size maintenance.
+
+Example:
+  pgedge-loadgen apps describe wholesale
+  pgedge-loadgen apps describe analytics --size 10GB
+  pgedge-loadgen apps describe ecommerce --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAppsDescribe,
+}
+
+func init() {
+	appsCmd.Flags().StringVar(&appsFormat, "format", "text",
+		"output format: text or json")
+
+	appsDescribeCmd.Flags().StringVar(&appsDescribeFormat, "format", "text",
+		"output format: text or json")
+	appsDescribeCmd.Flags().StringVar(&appsDescribeSize, "size", "",
+		"target database size for row count estimates (default: init.size)")
+
+	appsCmd.AddCommand(appsDescribeCmd)
+}
+
+// appSummary is the summary of an application in the application list.
+type appSummary struct {
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+	WorkloadType     string `json:"workload_type"`
+	RequiresPgvector bool   `json:"requires_pgvector"`
+}
+
+// appDescription is the description of an application.
+type appDescription struct {
+	Name             string             `json:"name"`
+	Description      string             `json:"description"`
+	WorkloadType     string             `json:"workload_type"`
+	RequiresPgvector bool               `json:"requires_pgvector"`
+	SizeMaintenance  bool               `json:"size_maintenance"`
+	TargetSize       string             `json:"target_size"`
+	EstimatedSize    int64              `json:"estimated_size_bytes"`
+	Queries          []queryDescription `json:"queries"`
+	Tables           []tableDescription `json:"tables"`
+}
+
+// queryDescription is the description of a query in an application's mix.
+type queryDescription struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Type        string  `json:"type"`
+	Weight      int     `json:"weight"`
+	Percent     float64 `json:"percent"`
+}
+
+// tableDescription is the description of a table in an application's schema.
+type tableDescription struct {
+	Name          string  `json:"name"`
+	RowSize       int64   `json:"row_size_bytes"`
+	ScaleRatio    float64 `json:"scale_ratio"`
+	IndexFactor   float64 `json:"index_factor"`
+	EstimatedRows int64   `json:"estimated_rows"`
+}
+
+func runApps(cmd *cobra.Command, args []string) error {
+	if err := validateFormat(appsFormat); err != nil {
+		return err
+	}
+
+	var summaries []appSummary
+	for _, a := range apps.All() {
+		summaries = append(summaries, appSummary{
+			Name:             a.Name(),
+			Description:      a.Description(),
+			WorkloadType:     a.WorkloadType(),
+			RequiresPgvector: a.RequiresPgvector(),
+		})
+	}
+
+	// Group pgvector applications after the others, and order each group
+	// by workload type so similar applications are listed together.
+	sort.SliceStable(summaries, func(i, j int) bool {
+		a, b := summaries[i], summaries[j]
+		if a.RequiresPgvector != b.RequiresPgvector {
+			return !a.RequiresPgvector
+		}
+		return a.WorkloadType < b.WorkloadType
+	})
+
+	out := cmd.OutOrStdout()
+	if appsFormat == "json" {
+		return writeJSON(out, summaries)
+	}
+
+	fmt.Fprintln(out, "Available applications:")
+	printAppGroup(out, "Standard Applications:", summaries, false)
+	printAppGroup(out, "pgvector Applications:", summaries, true)
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Use 'pgedge-loadgen apps describe <app>' for details.")
+	return nil
+}
+
+// printAppGroup prints the applications with the given pgvector
+// requirement under a heading, if there are any.
+func printAppGroup(out io.Writer, heading string, summaries []appSummary, pgvector bool) {
+	var group []appSummary
+	for _, s := range summaries {
+		if s.RequiresPgvector == pgvector {
+			group = append(group, s)
+		}
+	}
+	if len(group) == 0 {
+		return
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, heading)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, s := range group {
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", s.Name, s.WorkloadType, shortDescription(s.Description))
+	}
+	w.Flush()
+}
+
+// shortDescription returns the part of a description before the first
+// " - " separator.
+func shortDescription(description string) string {
+	short, _, _ := strings.Cut(description, " - ")
+	return short
+}
+
+func runAppsDescribe(cmd *cobra.Command, args []string) error {
+	if err := validateFormat(appsDescribeFormat); err != nil {
+		return err
+	}
+
+	application, err := apps.Get(args[0])
+	if err != nil {
+		return err
+	}
+
+	sizeStr := appsDescribeSize
+	if sizeStr == "" {
+		sizeStr = cfg.Init.Size
+	}
+	targetSize, err := parseSize(sizeStr)
+	if err != nil {
+		return err
+	}
+
+	desc := describeApp(application, sizeStr, targetSize)
+
+	out := cmd.OutOrStdout()
+	if appsDescribeFormat == "json" {
+		return writeJSON(out, desc)
+	}
+
+	printAppDescription(out, desc)
+	return nil
+}
+
+// describeApp builds the description of an application, estimating table
+// row counts for the given target size.
+func describeApp(application apps.App, sizeStr string, targetSize int64) appDescription {
+	_, maintainer := application.(apps.SizeMaintainer)
+
+	desc := appDescription{
+		Name:             application.Name(),
+		Description:      application.Description(),
+		WorkloadType:     application.WorkloadType(),
+		RequiresPgvector: application.RequiresPgvector(),
+		SizeMaintenance:  maintainer,
+		TargetSize:       sizeStr,
+	}
+
+	queries := application.GetQueries()
+	var totalWeight int
+	for _, q := range queries {
+		totalWeight += q.Weight
+	}
+	for _, q := range queries {
+		var percent float64
+		if totalWeight > 0 {
+			percent = float64(q.Weight) / float64(totalWeight) * 100
+		}
+		desc.Queries = append(desc.Queries, queryDescription{
+			Name:        q.Name,
+			Description: q.Description,
+			Type:        q.Type,
+			Weight:      q.Weight,
+			Percent:     percent,
+		})
+	}
+
+	tables := application.GetTables()
+	sizes := make([]datagen.TableSizeInfo, len(tables))
+	for i, t := range tables {
+		sizes[i] = datagen.TableSizeInfo{
+			Name:        t.Name,
+			BaseRowSize: t.BaseRowSize,
+			ScaleRatio:  t.ScaleRatio,
+			IndexFactor: t.IndexFactor,
+		}
+	}
+	calc := datagen.NewSizeCalculator(sizes)
+	rowCounts := calc.CalculateRowCounts(targetSize)
+	desc.EstimatedSize = calc.EstimatedSize(rowCounts)
+
+	for _, t := range tables {
+		desc.Tables = append(desc.Tables, tableDescription{
+			Name:          t.Name,
+			RowSize:       t.BaseRowSize,
+			ScaleRatio:    t.ScaleRatio,
+			IndexFactor:   t.IndexFactor,
+			EstimatedRows: rowCounts[t.Name],
+		})
+	}
+
+	return desc
+}
+
+// printAppDescription prints an application description as text.
+func printAppDescription(out io.Writer, desc appDescription) {
+	fmt.Fprintf(out, "%s - %s\n\n", desc.Name, desc.Description)
+	fmt.Fprintf(out, "Workload type:     %s\n", desc.WorkloadType)
+	fmt.Fprintf(out, "Requires pgvector: %s\n", yesNo(desc.RequiresPgvector))
+	fmt.Fprintf(out, "Size maintenance:  %s\n", yesNo(desc.SizeMaintenance))
+	fmt.Fprintln(out)
+
+	fmt.Fprintln(out, "Queries:")
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  NAME\tTYPE\tWEIGHT\tMIX\tDESCRIPTION")
+	for _, q := range desc.Queries {
+		fmt.Fprintf(w, "  %s\t%s\t%d\t%.1f%%\t%s\n",
+			q.Name, q.Type, q.Weight, q.Percent, q.Description)
+	}
+	w.Flush()
+	fmt.Fprintln(out)
+
+	fmt.Fprintf(out, "Tables (estimated for %s, ~%s with indexes):\n",
+		desc.TargetSize, datagen.FormatSize(desc.EstimatedSize))
+	w = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  NAME\tROW SIZE\tSCALE RATIO\tINDEX FACTOR\tEST. ROWS")
+	for _, t := range desc.Tables {
+		fmt.Fprintf(w, "  %s\t%d B\t%g\t%.2f\t%d\n",
+			t.Name, t.RowSize, t.ScaleRatio, t.IndexFactor, t.EstimatedRows)
+	}
+	w.Flush()
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+// validateFormat checks that an output format is supported.
+func validateFormat(format string) error {
+	if format != "text" && format != "json" {
+		return fmt.Errorf("format must be 'text' or 'json'")
+	}
+	return nil
+}
+
+// writeJSON writes v as indented JSON.
+func writeJSON(out io.Writer, v any) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
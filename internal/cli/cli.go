@@ -11,10 +11,14 @@
 package cli
 
 import (
+	"strings"
+
 	"github.com/spf13/cobra"
 
+	"github.com/pgEdge/pgedge-loadgen/internal/apps"
 	"github.com/pgEdge/pgedge-loadgen/internal/config"
 	"github.com/pgEdge/pgedge-loadgen/internal/logging"
+	"github.com/pgEdge/pgedge-loadgen/internal/workload/profiles"
 	"github.com/pgEdge/pgedge-loadgen/pkg/version"
 )
 
@@ -23,7 +27,10 @@ var (
 	cfgFile    string
 	connection string
 	app        string
+	schema     string
 	logLevel   string
+	logFormat  string
+	logFile    string
 
 	// Global config
 	cfg *config.Config
@@ -48,6 +55,11 @@ time-of-day variations, weekday/weekend differences, and seasonal patterns.`,
 
 // Execute runs the root command.
 func Execute() error {
+	// Applications register themselves from their package init functions,
+	// so the list of valid names is only complete once main has started.
+	rootCmd.PersistentFlags().Lookup("app").Usage =
+		"application type (" + strings.Join(apps.List(), ", ") + ")"
+
 	return rootCmd.Execute()
 }
 
@@ -58,9 +70,15 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&connection, "connection", "",
 		"PostgreSQL connection string")
 	rootCmd.PersistentFlags().StringVar(&app, "app", "",
-		"application type (wholesale, analytics, brokerage, retail, ecommerce, knowledgebase, docmgmt)")
+		"application type")
+	rootCmd.PersistentFlags().StringVar(&schema, "schema", "",
+		"PostgreSQL schema for the app's tables and metadata")
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "",
 		"log level (debug, info, warn, error)")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "",
+		"log format (console, json)")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "",
+		"log to this file instead of stderr, rotating it by size")
 
 	// Add subcommands
 	rootCmd.AddCommand(versionCmd)
@@ -68,6 +86,7 @@ func init() {
 	rootCmd.AddCommand(runCmd)
 	rootCmd.AddCommand(appsCmd)
 	rootCmd.AddCommand(profilesCmd)
+	rootCmd.AddCommand(compareCmd)
 }
 
 func initConfig() error {
@@ -84,17 +103,35 @@ func initConfig() error {
 	if app != "" {
 		cfg.App = app
 	}
+	if schema != "" {
+		cfg.Schema = schema
+	}
 	if logLevel != "" {
 		cfg.LogLevel = logLevel
 	}
+	if logFormat != "" {
+		cfg.LogFormat = logFormat
+	}
+	if logFile != "" {
+		cfg.LogFile = logFile
+	}
+
+	// Register custom profiles from the config file
+	if err := profiles.RegisterDefinitions(cfg.Profiles); err != nil {
+		return err
+	}
 
 	// Reinitialize logger with config
-	logging.Init(logging.Config{
-		Level:  cfg.LogLevel,
-		Pretty: true,
+	if err := cfg.ValidateLogging(); err != nil {
+		return err
+	}
+	return logging.Init(logging.Config{
+		Level:      cfg.LogLevel,
+		Pretty:     cfg.LogFormat == "console",
+		File:       cfg.LogFile,
+		MaxSize:    int64(cfg.LogMaxSize) << 20,
+		MaxBackups: cfg.LogMaxBackups,
 	})
-
-	return nil
 }
 
 var versionCmd = &cobra.Command{
@@ -104,46 +141,3 @@ var versionCmd = &cobra.Command{
 		cmd.Println(version.Info())
 	},
 }
-
-var appsCmd = &cobra.Command{
-	Use:   "apps",
-	Short: "List available applications",
-	Long: `List all available fictional applications that can be used for
-load generation. Each application has a unique schema and query mix.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		cmd.Println("Available applications:")
-		cmd.Println()
-		cmd.Println("TPC-Based Applications:")
-		cmd.Println("  wholesale     - Wholesale supplier (TPC-C based) - OLTP workload")
-		cmd.Println("  analytics     - Analytics warehouse (TPC-H based) - OLAP workload")
-		cmd.Println("  brokerage     - Brokerage firm (TPC-E based) - Mixed workload")
-		cmd.Println("  retail        - Retail analytics (TPC-DS based) - Decision support")
-		cmd.Println()
-		cmd.Println("pgvector Applications:")
-		cmd.Println("  ecommerce     - E-commerce with semantic product search")
-		cmd.Println("  knowledgebase - Knowledge base with semantic article search")
-		cmd.Println("  docmgmt       - Document management with similarity search")
-		cmd.Println()
-		cmd.Println("Use 'pgedge-loadgen apps describe <app>' for details.")
-	},
-}
-
-var profilesCmd = &cobra.Command{
-	Use:   "profiles",
-	Short: "List available usage profiles",
-	Long: `List all available usage profiles that simulate different
-patterns of database activity based on time of day and week.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		cmd.Println("Available usage profiles:")
-		cmd.Println()
-		cmd.Println("  local-office   - Local office hours (8AM-6PM, weekday focus)")
-		cmd.Println("  global         - Global enterprise (24/7 with rolling peaks)")
-		cmd.Println("  store-regional - Online store, regional (evening peak)")
-		cmd.Println("  store-global   - Online store, global (24/7 multi-region)")
-		cmd.Println()
-		cmd.Println("Profiles affect:")
-		cmd.Println("  - Query rate variations throughout the day")
-		cmd.Println("  - Weekend vs weekday activity levels")
-		cmd.Println("  - Break and lunch time reductions")
-	},
-}
@@ -0,0 +1,158 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pgEdge/pgedge-loadgen/internal/report"
+)
+
+var (
+	compareMaxQPSDecrease       float64
+	compareMaxErrorRateIncrease float64
+	compareMaxLatencyIncrease   float64
+	comparePercentile           string
+)
+
+var compareCmd = &cobra.Command{
+	Use:   "compare <baseline> <report> [report...]",
+	Short: "Compare run reports to detect regressions",
+	Long: `Compare two or more JSON run reports written with 'run --report-file'.
+Each report is compared against the first (baseline) report, showing the
+per-query change in throughput, error rate and latency percentiles.
+
+If any threshold is set and exceeded, the regressions are listed and the
+command exits with a non-zero status.
+
+Example:
+  pgedge-loadgen compare before.json after.json
+  pgedge-loadgen compare before.json after.json --max-latency-increase 20 --percentile p95
+  pgedge-loadgen compare before.json after.json --max-qps-decrease 10 --max-error-rate-increase 0.5`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runCompare,
+}
+
+func init() {
+	compareCmd.Flags().Float64Var(&compareMaxQPSDecrease, "max-qps-decrease", 0,
+		"fail if throughput decreases by more than this percentage (0 = disabled)")
+	compareCmd.Flags().Float64Var(&compareMaxErrorRateIncrease, "max-error-rate-increase", 0,
+		"fail if the error rate increases by more than this many percentage points (0 = disabled)")
+	compareCmd.Flags().Float64Var(&compareMaxLatencyIncrease, "max-latency-increase", 0,
+		"fail if latency at --percentile increases by more than this percentage (0 = disabled)")
+	compareCmd.Flags().StringVar(&comparePercentile, "percentile", "p99",
+		"latency percentile checked by --max-latency-increase: "+strings.Join(report.Percentiles, ", "))
+}
+
+func runCompare(cmd *cobra.Command, args []string) error {
+	thresholds := report.Thresholds{
+		MaxQPSDecrease:       compareMaxQPSDecrease,
+		MaxErrorRateIncrease: compareMaxErrorRateIncrease,
+		MaxLatencyIncrease:   compareMaxLatencyIncrease,
+		Percentile:           comparePercentile,
+	}
+
+	baseline, err := report.Load(args[0])
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	var regressions []string
+
+	for _, path := range args[1:] {
+		other, err := report.Load(path)
+		if err != nil {
+			return err
+		}
+
+		comparison, err := report.Compare(baseline, other, thresholds)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(out, "Baseline: %s\n", describeReport(args[0], baseline))
+		fmt.Fprintf(out, "Compared: %s\n\n", describeReport(path, other))
+		printComparison(out, comparison)
+		fmt.Fprintln(out)
+
+		for _, r := range comparison.Regressions {
+			regressions = append(regressions, fmt.Sprintf("%s: %s", path, r))
+		}
+	}
+
+	if len(regressions) > 0 {
+		fmt.Fprintln(out, "Regressions:")
+		for _, r := range regressions {
+			fmt.Fprintf(out, "  %s\n", r)
+		}
+		return fmt.Errorf("%d regression threshold(s) exceeded", len(regressions))
+	}
+
+	return nil
+}
+
+// describeReport returns a one-line description of a report.
+func describeReport(path string, r *report.Report) string {
+	duration := time.Duration(r.DurationSeconds * float64(time.Second)).Round(time.Second)
+	return fmt.Sprintf("%s (app=%s profile=%s mode=%s start=%s duration=%s)",
+		path, r.App, r.Profile, r.ConnectionMode,
+		r.StartTime.UTC().Format(time.RFC3339), duration)
+}
+
+// printComparison prints a per-query comparison table.
+func printComparison(out io.Writer, c *report.Comparison) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	header := []string{"QUERY", "QPS", "CHANGE", "ERROR%", "CHANGE"}
+	for _, p := range report.Percentiles {
+		header = append(header, strings.ToUpper(p)+"_MS", "CHANGE")
+	}
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+
+	for _, q := range c.Queries {
+		row := []string{q.Name}
+		switch {
+		case q.Baseline == nil:
+			row = append(row, "(new)")
+		case q.Other == nil:
+			row = append(row, "(missing)")
+		default:
+			base, other := q.Baseline, q.Other
+			row = append(row,
+				fmt.Sprintf("%.2f", other.QPS),
+				formatPercentChange(base.QPS, other.QPS),
+				fmt.Sprintf("%.2f", other.ErrorRate()*100),
+				fmt.Sprintf("%+.2f", (other.ErrorRate()-base.ErrorRate())*100))
+			for _, p := range report.Percentiles {
+				b, _ := report.LatencyPercentile(base.Latency, p)
+				o, _ := report.LatencyPercentile(other.Latency, p)
+				row = append(row, fmt.Sprintf("%.2f", o), formatPercentChange(b, o))
+			}
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+
+	w.Flush()
+}
+
+// formatPercentChange formats the change from base to other in percent.
+func formatPercentChange(base, other float64) string {
+	if base == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%+.1f%%", report.PercentChange(base, other))
+}
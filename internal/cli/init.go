@@ -12,10 +12,12 @@ package cli
 import (
 	"context"
 	"fmt"
+	"math/rand/v2"
 
 	"github.com/spf13/cobra"
 
 	"github.com/pgEdge/pgedge-loadgen/internal/apps"
+	"github.com/pgEdge/pgedge-loadgen/internal/config"
 	"github.com/pgEdge/pgedge-loadgen/internal/db"
 	"github.com/pgEdge/pgedge-loadgen/internal/logging"
 )
@@ -28,6 +30,7 @@ var (
 	initOpenAIAPIKey        string
 	initDropExisting        bool
 	initConcurrency         int
+	initSeed                uint64
 )
 
 var initCmd = &cobra.Command{
@@ -38,7 +41,8 @@ for the specified application. The target size parameter controls how
 much data is generated.
 
 Example:
-  pgedge-loadgen init --app wholesale --size 5GB --connection "postgres://..."`,
+  pgedge-loadgen init --app wholesale --size 5GB --connection "postgres://..."
+  pgedge-loadgen init --app wholesale --size 1GB --seed 42`,
 	RunE: runInit,
 }
 
@@ -57,6 +61,8 @@ func init() {
 		"drop existing schema before initialization")
 	initCmd.Flags().IntVar(&initConcurrency, "concurrency", 0,
 		"number of concurrent database connections (default: 4)")
+	initCmd.Flags().Uint64Var(&initSeed, "seed", 0,
+		"random seed for data generation (default: random)")
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
@@ -82,82 +88,125 @@ func runInit(cmd *cobra.Command, args []string) error {
 	if initConcurrency > 0 {
 		cfg.Init.Concurrency = initConcurrency
 	}
+	if initSeed > 0 {
+		cfg.Init.Seed = initSeed
+	}
 
 	// Validate configuration
 	if err := cfg.ValidateInit(); err != nil {
 		return err
 	}
 
-	// Get the application
-	application, err := apps.Get(cfg.App)
+	cfg.Init.Seed = seedOrRandom(cfg.Init.Seed)
+
+	// Check that all the applications exist before initializing any
+	workloads := cfg.ResolveWorkloads()
+	for _, w := range workloads {
+		if _, err := apps.Get(w.App); err != nil {
+			return err
+		}
+	}
+
+	ctx := context.Background()
+	for _, w := range workloads {
+		if err := initWorkload(ctx, w); err != nil {
+			return w.WrapError(err)
+		}
+	}
+
+	return nil
+}
+
+// initWorkload creates the schema and generates the data for a workload.
+func initWorkload(ctx context.Context, w config.Workload) error {
+	application, err := apps.Get(w.App)
 	if err != nil {
 		return err
 	}
 
-	logging.Info().
-		Str("app", cfg.App).
-		Str("size", cfg.Init.Size).
+	// Tag log lines with the schema when initializing into one
+	log := logging.Logger
+	if w.Schema != "" {
+		log = log.With().Str("schema", w.Schema).Logger()
+	}
+
+	log.Info().
+		Str("app", w.App).
+		Str("size", w.Init.Size).
+		Uint64("seed", w.Init.Seed).
 		Msg("Initializing database")
 
 	// Connect to database
-	ctx := context.Background()
-	pool, err := db.Connect(ctx, cfg.Connection, int32(cfg.Init.Concurrency), "init")
+	pool, err := db.Connect(ctx, cfg.Connection, w.Schema, nil, int32(w.Init.Concurrency), "init")
 	if err != nil {
 		return fmt.Errorf("failed to connect to database: %w", err)
 	}
 	defer pool.Close()
 
 	// Check if already initialized for a different app
-	existingApp, err := db.GetMetadataValue(ctx, pool, "app")
+	existingApp, err := db.GetMetadataValue(ctx, pool, w.Schema, "app")
 	if err == nil && existingApp != "" {
-		if existingApp != cfg.App {
-			if !cfg.Init.DropExisting {
+		if existingApp != w.App {
+			if !w.Init.DropExisting {
 				return fmt.Errorf(
 					"database was initialized for '%s' but '%s' was specified; "+
 						"use --drop-existing to reinitialize",
-					existingApp, cfg.App)
+					existingApp, w.App)
 			}
-			logging.Warn().
+			log.Warn().
 				Str("existing_app", existingApp).
-				Str("new_app", cfg.App).
+				Str("new_app", w.App).
 				Msg("Dropping existing schema")
 		}
 	}
 
-	// Drop existing schema if requested
-	if cfg.Init.DropExisting {
-		logging.Info().Msg("Dropping existing schema")
-		if err := application.DropSchema(ctx, pool); err != nil {
-			return fmt.Errorf("failed to drop schema: %w", err)
-		}
-		if err := db.DropMetadata(ctx, pool); err != nil {
-			logging.Debug().Err(err).Msg("No metadata table to drop")
+	// Drop existing schema if requested. A named schema is dropped as a
+	// whole, including its metadata.
+	if w.Init.DropExisting {
+		log.Info().Msg("Dropping existing schema")
+		if w.Schema != "" {
+			if err := db.DropSchema(ctx, pool, w.Schema); err != nil {
+				return err
+			}
+		} else {
+			if err := application.DropSchema(ctx, pool); err != nil {
+				return fmt.Errorf("failed to drop schema: %w", err)
+			}
+			if err := db.DropMetadata(ctx, pool, w.Schema); err != nil {
+				log.Debug().Err(err).Msg("No metadata table to drop")
+			}
 		}
 	}
 
 	// Create schema
-	logging.Info().Msg("Creating schema")
+	log.Info().Msg("Creating schema")
+	if w.Schema != "" {
+		if err := db.CreateSchema(ctx, pool, w.Schema); err != nil {
+			return err
+		}
+	}
 	if err := application.CreateSchema(ctx, pool); err != nil {
 		return fmt.Errorf("failed to create schema: %w", err)
 	}
 
 	// Parse target size
-	targetBytes, err := parseSize(cfg.Init.Size)
+	targetBytes, err := parseSize(w.Init.Size)
 	if err != nil {
 		return fmt.Errorf("invalid size: %w", err)
 	}
 
 	// Generate data
-	logging.Info().
+	log.Info().
 		Int64("target_bytes", targetBytes).
 		Msg("Generating test data")
 
 	genCfg := apps.GeneratorConfig{
 		TargetSize:          targetBytes,
-		EmbeddingMode:       cfg.Init.EmbeddingMode,
-		EmbeddingDimensions: cfg.Init.EmbeddingDimensions,
-		VectorizerURL:       cfg.Init.VectorizerURL,
-		OpenAIAPIKey:        cfg.Init.OpenAIAPIKey,
+		EmbeddingMode:       w.Init.EmbeddingMode,
+		EmbeddingDimensions: w.Init.EmbeddingDimensions,
+		VectorizerURL:       w.Init.VectorizerURL,
+		OpenAIAPIKey:        w.Init.OpenAIAPIKey,
+		Seed:                w.Init.Seed,
 	}
 
 	if err := application.GenerateData(ctx, pool, genCfg); err != nil {
@@ -165,13 +214,13 @@ func runInit(cmd *cobra.Command, args []string) error {
 	}
 
 	// Save metadata
-	if err := db.SaveMetadata(ctx, pool, cfg.App, cfg.Init.Size); err != nil {
+	if err := db.SaveMetadata(ctx, pool, w.Schema, w.App, w.Init.Size, w.Init.Seed); err != nil {
 		return fmt.Errorf("failed to save metadata: %w", err)
 	}
 
-	logging.Info().
-		Str("app", cfg.App).
-		Str("size", cfg.Init.Size).
+	log.Info().
+		Str("app", w.App).
+		Str("size", w.Init.Size).
 		Msg("Database initialization complete")
 
 	return nil
@@ -205,3 +254,11 @@ func parseSize(s string) (int64, error) {
 
 	return int64(value * float64(multiplier)), nil
 }
+
+// seedOrRandom returns seed, or a random non-zero seed if seed is 0.
+func seedOrRandom(seed uint64) uint64 {
+	for seed == 0 {
+		seed = rand.Uint64()
+	}
+	return seed
+}
@@ -0,0 +1,79 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package cli
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pgEdge/pgedge-loadgen/internal/workload/profiles"
+)
+
+var profilesFormat string
+
+var profilesCmd = &cobra.Command{
+	Use:   "profiles",
+	Short: "List available usage profiles",
+	Long: `List all available usage profiles that simulate different
+patterns of database activity based on time of day and week.`,
+	Args: cobra.NoArgs,
+	RunE: runProfiles,
+}
+
+func init() {
+	profilesCmd.Flags().StringVar(&profilesFormat, "format", "text",
+		"output format: text or json")
+}
+
+// profileSummary is the summary of a profile in the profile list.
+type profileSummary struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func runProfiles(cmd *cobra.Command, args []string) error {
+	if err := validateFormat(profilesFormat); err != nil {
+		return err
+	}
+
+	var summaries []profileSummary
+	for _, name := range profiles.List() {
+		// The description does not depend on the timezone.
+		p, err := profiles.Get(name, "UTC")
+		if err != nil {
+			return err
+		}
+		summaries = append(summaries, profileSummary{
+			Name:        p.Name(),
+			Description: p.Description(),
+		})
+	}
+
+	out := cmd.OutOrStdout()
+	if profilesFormat == "json" {
+		return writeJSON(out, summaries)
+	}
+
+	fmt.Fprintln(out, "Available usage profiles:")
+	fmt.Fprintln(out)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, s := range summaries {
+		fmt.Fprintf(w, "  %s\t%s\n", s.Name, s.Description)
+	}
+	w.Flush()
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Profiles affect:")
+	fmt.Fprintln(out, "  - Query rate variations throughout the day")
+	fmt.Fprintln(out, "  - Weekend vs weekday activity levels")
+	fmt.Fprintln(out, "  - Break and lunch time reductions")
+	return nil
+}
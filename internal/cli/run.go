@@ -11,17 +11,22 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 
 	"github.com/pgEdge/pgedge-loadgen/internal/apps"
+	"github.com/pgEdge/pgedge-loadgen/internal/config"
 	"github.com/pgEdge/pgedge-loadgen/internal/db"
 	"github.com/pgEdge/pgedge-loadgen/internal/logging"
+	"github.com/pgEdge/pgedge-loadgen/internal/report"
 	"github.com/pgEdge/pgedge-loadgen/internal/workload"
 )
 
@@ -32,11 +37,28 @@ var (
 	runReportInterval     int
 	runDuration           int
 	runConnectionMode     string
+	runPoolSize           int
+	runChurnQueries       int
 	runSessionMinDuration int
 	runSessionMaxDuration int
 	runThinkTimeMin       int
 	runThinkTimeMax       int
 	runNoMaintainSize     bool
+	runTargetQPS          float64
+	runMetricsListen      string
+	runReportFile         string
+	runTimeSeriesFile     string
+	runQueryWeights       map[string]int
+	runTimeScale          float64
+	runStartTime          string
+	runSeed               uint64
+	runAvailability       bool
+	runQueryTimeout       int
+	runQueryTimeouts      map[string]int
+	runStatementTimeout   int
+	runLockTimeout        int
+	runServerStats        bool
+	runTUI                bool
 )
 
 var runCmd = &cobra.Command{
@@ -47,13 +69,29 @@ initialized with the 'init' command. The simulation will continue until
 interrupted with Ctrl+C or until the specified duration expires.
 
 Connection Modes:
-  pool    - Connections are shared and reused rapidly (web apps, default)
+  pool    - Connections are shared and reused rapidly (web apps, default);
+            with --pool-size, the clients share a pool of that many connections
   session - Workers simulate user sessions with think time (desktop apps)
+  churn   - Workers open a new connection for each session, or every
+            --churn-queries queries (serverless functions, poolers)
 
 Example:
   pgedge-loadgen run --app wholesale --connections 50 --profile local-office
   pgedge-loadgen run --app wholesale --connections 50 --duration 30
-  pgedge-loadgen run --app brokerage --connections 20 --connection-mode session`,
+  pgedge-loadgen run --app brokerage --connections 20 --connection-mode session
+  pgedge-loadgen run --app wholesale --connections 200 --pool-size 20
+  pgedge-loadgen run --app wholesale --connections 50 --connection-mode churn --churn-queries 5
+  pgedge-loadgen run --app wholesale --connections 50 --target-qps 500
+  pgedge-loadgen run --app wholesale --connections 50 --metrics-listen :9102
+  pgedge-loadgen run --app wholesale --duration 60 --report-file summary.json
+  pgedge-loadgen run --app wholesale --report-interval 300 --timeseries-file timeseries.csv
+  pgedge-loadgen run --app analytics --query-weight pricing_summary=5
+  pgedge-loadgen run --app analytics --query-timeout 2000 --query-timeout-for pricing_summary=30000
+  pgedge-loadgen run --app wholesale --time-scale 168 --start-time "2026-01-05 00:00" --duration 60
+  pgedge-loadgen run --app wholesale --connections 10 --duration 10 --seed 42
+  pgedge-loadgen run --app wholesale --availability --connection "host=node1,node2 dbname=app"
+  pgedge-loadgen run --app wholesale --duration 60 --server-stats --report-file summary.json
+  pgedge-loadgen run --app wholesale --connections 50 --tui`,
 	RunE: runRun,
 }
 
@@ -61,7 +99,7 @@ func init() {
 	runCmd.Flags().IntVar(&runConnections, "connections", 0,
 		"number of database connections")
 	runCmd.Flags().StringVar(&runProfile, "profile", "",
-		"usage profile: local-office, global, store-regional, store-global")
+		"usage profile (see 'pgedge-loadgen profiles')")
 	runCmd.Flags().StringVar(&runTimezone, "timezone", "",
 		"timezone for profile calculations (default: Local)")
 	runCmd.Flags().IntVar(&runReportInterval, "report-interval", 0,
@@ -69,7 +107,11 @@ func init() {
 	runCmd.Flags().IntVar(&runDuration, "duration", 0,
 		"duration to run in minutes (0 = run indefinitely)")
 	runCmd.Flags().StringVar(&runConnectionMode, "connection-mode", "",
-		"connection mode: pool (web apps) or session (desktop apps)")
+		"connection mode: pool (web apps), session (desktop apps) or churn (short-lived clients)")
+	runCmd.Flags().IntVar(&runPoolSize, "pool-size", 0,
+		"number of connections in a pool shared by the clients (0 = one per client, pool mode only)")
+	runCmd.Flags().IntVar(&runChurnQueries, "churn-queries", 0,
+		"queries per connection in churn mode (0 = one connection per session)")
 	runCmd.Flags().IntVar(&runSessionMinDuration, "session-min-duration", 0,
 		"minimum session duration in seconds (session mode only)")
 	runCmd.Flags().IntVar(&runSessionMaxDuration, "session-max-duration", 0,
@@ -80,6 +122,36 @@ func init() {
 		"maximum think time between queries in milliseconds (session mode only)")
 	runCmd.Flags().BoolVar(&runNoMaintainSize, "no-maintain-size", false,
 		"disable automatic cleanup of old data to maintain target database size")
+	runCmd.Flags().Float64Var(&runTargetQPS, "target-qps", 0,
+		"issue queries at a fixed rate at full activity, dropping arrivals beyond a queue of 100 per connection (0 = closed loop, pool mode only)")
+	runCmd.Flags().StringVar(&runMetricsListen, "metrics-listen", "",
+		"address to serve Prometheus metrics on, e.g. :9102 (default: disabled)")
+	runCmd.Flags().StringVar(&runReportFile, "report-file", "",
+		"write a run summary report to this file (.json or .csv)")
+	runCmd.Flags().StringVar(&runTimeSeriesFile, "timeseries-file", "",
+		"write each report interval's statistics to this CSV file as the run progresses")
+	runCmd.Flags().StringToIntVar(&runQueryWeights, "query-weight", nil,
+		"override a query weight as name=N (repeatable, 0 disables the query)")
+	runCmd.Flags().Float64Var(&runTimeScale, "time-scale", 0,
+		"play back the usage profile this many times faster than real time (default: 1)")
+	runCmd.Flags().StringVar(&runStartTime, "start-time", "",
+		"profile time to start playback at, e.g. \"2026-01-05 06:00\" (default: now)")
+	runCmd.Flags().Uint64Var(&runSeed, "seed", 0,
+		"random seed for query parameters and session timings (default: random)")
+	runCmd.Flags().BoolVar(&runAvailability, "availability", false,
+		"detect outages and report downtime and time to recover per node")
+	runCmd.Flags().IntVar(&runQueryTimeout, "query-timeout", 0,
+		"cancel queries running longer than this many milliseconds (0 = no timeout)")
+	runCmd.Flags().StringToIntVar(&runQueryTimeouts, "query-timeout-for", nil,
+		"override the query timeout as name=ms (repeatable, 0 disables the timeout)")
+	runCmd.Flags().IntVar(&runStatementTimeout, "statement-timeout", 0,
+		"server statement_timeout for the clients' connections in milliseconds")
+	runCmd.Flags().IntVar(&runLockTimeout, "lock-timeout", 0,
+		"server lock_timeout for the clients' connections in milliseconds")
+	runCmd.Flags().BoolVar(&runServerStats, "server-stats", false,
+		"sample server statistics every report interval and report the changes")
+	runCmd.Flags().BoolVar(&runTUI, "tui", false,
+		"show a live dashboard instead of the log output (if stdout is a terminal)")
 }
 
 func runRun(cmd *cobra.Command, args []string) error {
@@ -102,6 +174,12 @@ func runRun(cmd *cobra.Command, args []string) error {
 	if runConnectionMode != "" {
 		cfg.Run.ConnectionMode = runConnectionMode
 	}
+	if runPoolSize > 0 {
+		cfg.Run.PoolSize = runPoolSize
+	}
+	if runChurnQueries > 0 {
+		cfg.Run.ChurnQueries = runChurnQueries
+	}
 	if runSessionMinDuration > 0 {
 		cfg.Run.SessionMinDuration = runSessionMinDuration
 	}
@@ -114,44 +192,258 @@ func runRun(cmd *cobra.Command, args []string) error {
 	if runThinkTimeMax > 0 {
 		cfg.Run.ThinkTimeMax = runThinkTimeMax
 	}
+	if runTargetQPS > 0 {
+		cfg.Run.TargetQPS = runTargetQPS
+	}
+	if runMetricsListen != "" {
+		cfg.Run.MetricsListen = runMetricsListen
+	}
+	if runReportFile != "" {
+		cfg.Run.ReportFile = runReportFile
+	}
+	if runTimeSeriesFile != "" {
+		cfg.Run.TimeSeriesFile = runTimeSeriesFile
+	}
+	if len(runQueryWeights) > 0 {
+		if cfg.Run.QueryWeights == nil {
+			cfg.Run.QueryWeights = make(map[string]int)
+		}
+		for name, weight := range runQueryWeights {
+			cfg.Run.QueryWeights[name] = weight
+		}
+	}
+	if runTimeScale > 0 {
+		cfg.Run.TimeScale = runTimeScale
+	}
+	if runStartTime != "" {
+		cfg.Run.StartTime = runStartTime
+	}
+	if runSeed > 0 {
+		cfg.Run.Seed = runSeed
+	}
+	if runAvailability {
+		cfg.Run.Availability = true
+	}
+	if runQueryTimeout > 0 {
+		cfg.Run.QueryTimeout = runQueryTimeout
+	}
+	if len(runQueryTimeouts) > 0 {
+		if cfg.Run.QueryTimeouts == nil {
+			cfg.Run.QueryTimeouts = make(map[string]int)
+		}
+		for name, timeout := range runQueryTimeouts {
+			cfg.Run.QueryTimeouts[name] = timeout
+		}
+	}
+	if runStatementTimeout > 0 {
+		cfg.Run.StatementTimeout = runStatementTimeout
+	}
+	if runLockTimeout > 0 {
+		cfg.Run.LockTimeout = runLockTimeout
+	}
+	if runServerStats {
+		cfg.Run.ServerStats = true
+	}
+	if runTUI {
+		cfg.Run.TUI = true
+	}
 
 	// Validate configuration
 	if err := cfg.ValidateRun(); err != nil {
 		return err
 	}
 
+	cfg.Run.Seed = seedOrRandom(cfg.Run.Seed)
+
+	// Prepare an executor for each workload
+	ctx := context.Background()
+	var runs []*workloadRun
+	for _, w := range cfg.ResolveWorkloads() {
+		run, err := prepareWorkload(ctx, w)
+		if err != nil {
+			return w.WrapError(err)
+		}
+		runs = append(runs, run)
+	}
+
+	for _, run := range runs {
+		logEvent := logging.Info().
+			Str("app", run.w.App)
+		if run.w.Schema != "" {
+			logEvent = logEvent.Str("schema", run.w.Schema)
+		}
+		logEvent.
+			Int("connections", run.w.Run.Connections).
+			Str("profile", run.w.Run.Profile).
+			Str("connection_mode", run.w.Run.ConnectionMode).
+			Float64("target_qps", run.w.Run.TargetQPS).
+			Str("duration", durationMsg(&run.w.Run)).
+			Msg("Starting load simulation")
+	}
+
+	// Set up context with cancellation (and optional timeout)
+	var cancel context.CancelFunc
+	if cfg.Run.Duration > 0 {
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(cfg.Run.Duration)*time.Minute)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	// Handle shutdown signals
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-sigChan
+		logging.Info().
+			Str("signal", sig.String()).
+			Msg("Received shutdown signal")
+		cancel()
+	}()
+
+	executors := make([]*workload.Executor, len(runs))
+	for i, run := range runs {
+		executors[i] = run.executor
+	}
+
+	// Start the Prometheus metrics endpoint if requested
+	if cfg.Run.MetricsListen != "" {
+		if err := workload.ServeMetrics(ctx, cfg.Run.MetricsListen, executors...); err != nil {
+			return err
+		}
+	}
+
+	// Show the live dashboard if requested, with the log output shown in
+	// its log pane while it runs
+	stopDashboard := func() {}
+	if cfg.Run.TUI {
+		if isatty.IsTerminal(os.Stdout.Fd()) || isatty.IsCygwinTerminal(os.Stdout.Fd()) {
+			dashboard := workload.NewDashboard(os.Stdout, executors...)
+			restore := logging.Redirect(dashboard)
+			dashCtx, cancelDashboard := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				dashboard.Run(dashCtx)
+			}()
+			stopDashboard = func() {
+				cancelDashboard()
+				<-done
+				restore()
+			}
+		} else {
+			logging.Warn().Msg("stdout is not a terminal; showing log output instead of the dashboard")
+		}
+	}
+
+	// Run the workloads concurrently until the context is cancelled
+	// (signal or timeout), or until they have all finished their phases
+	var wg sync.WaitGroup
+	errs := make([]error, len(runs))
+	for i, run := range runs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = run.w.WrapError(run.executor.Run(ctx))
+		}()
+	}
+	wg.Wait()
+	stopDashboard()
+
+	if err := errors.Join(errs...); err != nil {
+		if ctx.Err() == nil {
+			return fmt.Errorf("executor error: %w", err)
+		}
+	}
+
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		logging.Info().Msg("Duration limit reached, stopping simulation")
+	case ctx.Err() != nil:
+		logging.Info().Msg("Load simulation stopped")
+	default:
+		// Normal completion (only when all workloads have a phase schedule)
+		logging.Info().Msg("Load simulation completed")
+	}
+
+	for _, run := range runs {
+		run.executor.PrintSummary()
+		if err := run.writeReport(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// workloadRun is a workload prepared for the run command.
+type workloadRun struct {
+	w        config.Workload
+	executor *workload.Executor
+	metadata map[string]string
+}
+
+// prepareWorkload checks that the database was initialized for the
+// workload's app and creates its executor.
+func prepareWorkload(ctx context.Context, w config.Workload) (*workloadRun, error) {
+	startTime, err := w.Run.ProfileStartTime()
+	if err != nil {
+		return nil, err
+	}
+
+	phases, err := phaseSchedule(&w.Run)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get the application
-	application, err := apps.Get(cfg.App)
+	application, err := apps.Get(w.App)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	// Apply query weight overrides
+	if len(w.Run.QueryWeights) > 0 {
+		if err := apps.ValidateQueryWeights(application, w.Run.QueryWeights); err != nil {
+			return nil, err
+		}
+		application.SetQueryWeights(w.Run.QueryWeights)
+	}
+
+	// Convert the query timeouts
+	if err := apps.ValidateQueryTimeouts(application, w.Run.QueryTimeouts); err != nil {
+		return nil, err
+	}
+	queryTimeouts := make(map[string]time.Duration, len(w.Run.QueryTimeouts))
+	for name, timeout := range w.Run.QueryTimeouts {
+		queryTimeouts[name] = time.Duration(timeout) * time.Millisecond
 	}
 
 	// Connect to database for metadata check (single connection, not pool)
-	ctx := context.Background()
-	conn, err := db.ConnectSingle(ctx, cfg.Connection, "metadata")
+	conn, err := db.ConnectSingle(ctx, cfg.Connection, w.Schema, nil, "metadata")
 	if err != nil {
-		return fmt.Errorf("failed to connect to database: %w", err)
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
+	// Close metadata connection - workers will create their own connections
+	defer conn.Close(ctx)
 
 	// Check that database was initialized for this app
-	existingApp, err := db.GetMetadataValueConn(ctx, conn, "app")
+	existingApp, err := db.GetMetadataValueConn(ctx, conn, w.Schema, "app")
 	if err != nil {
-		return fmt.Errorf(
+		return nil, fmt.Errorf(
 			"database has not been initialized; run 'pgedge-loadgen init' first")
 	}
-	if existingApp != cfg.App {
-		conn.Close(ctx)
-		return fmt.Errorf(
+	if existingApp != w.App {
+		return nil, fmt.Errorf(
 			"database was initialized for '%s' but '%s' was specified; "+
 				"re-run with --app=%s or initialize a new database",
-			existingApp, cfg.App, existingApp)
+			existingApp, w.App, existingApp)
 	}
 
 	// Get target size for size maintenance
 	var targetSize int64
 	maintainSize := !runNoMaintainSize
 	if maintainSize {
-		targetSizeStr, err := db.GetMetadataValueConn(ctx, conn, "target_size")
+		targetSizeStr, err := db.GetMetadataValueConn(ctx, conn, w.Schema, "target_size")
 		if err != nil {
 			logging.Warn().
 				Msg("Could not retrieve target_size; size maintenance disabled")
@@ -167,79 +459,117 @@ func runRun(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Close metadata connection - workers will create their own connections
-	conn.Close(ctx)
-
-	durationMsg := "indefinitely"
-	if cfg.Run.Duration > 0 {
-		durationMsg = fmt.Sprintf("%d minutes", cfg.Run.Duration)
-	}
-
-	logging.Info().
-		Str("app", cfg.App).
-		Int("connections", cfg.Run.Connections).
-		Str("profile", cfg.Run.Profile).
-		Str("connection_mode", cfg.Run.ConnectionMode).
-		Str("duration", durationMsg).
-		Msg("Starting load simulation")
-
-	// Set up context with cancellation (and optional timeout)
-	var cancel context.CancelFunc
-	if cfg.Run.Duration > 0 {
-		ctx, cancel = context.WithTimeout(ctx, time.Duration(cfg.Run.Duration)*time.Minute)
-	} else {
-		ctx, cancel = context.WithCancel(ctx)
+	// Get all metadata for the run report
+	var metadata map[string]string
+	if w.Run.ReportFile != "" {
+		metadata, err = db.GetAllMetadataConn(ctx, conn, w.Schema)
+		if err != nil {
+			logging.Warn().Err(err).
+				Msg("Could not retrieve metadata for run report")
+		}
 	}
-	defer cancel()
 
-	// Handle shutdown signals
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-	go func() {
-		sig := <-sigChan
-		logging.Info().
-			Str("signal", sig.String()).
-			Msg("Received shutdown signal")
-		cancel()
-	}()
-
-	// Create and run the workload executor
+	// Create the workload executor
 	executor, err := workload.NewExecutor(workload.ExecutorConfig{
 		ConnString:         cfg.Connection,
+		Schema:             w.Schema,
 		App:                application,
-		Connections:        cfg.Run.Connections,
-		Profile:            cfg.Run.Profile,
-		Timezone:           cfg.Run.Timezone,
-		ReportInterval:     cfg.Run.ReportInterval,
-		ConnectionMode:     cfg.Run.ConnectionMode,
-		SessionMinDuration: cfg.Run.SessionMinDuration,
-		SessionMaxDuration: cfg.Run.SessionMaxDuration,
-		ThinkTimeMin:       cfg.Run.ThinkTimeMin,
-		ThinkTimeMax:       cfg.Run.ThinkTimeMax,
+		Connections:        w.Run.Connections,
+		Profile:            w.Run.Profile,
+		Timezone:           w.Run.Timezone,
+		ReportInterval:     w.Run.ReportInterval,
+		ConnectionMode:     w.Run.ConnectionMode,
+		PoolSize:           w.Run.PoolSize,
+		ChurnQueries:       w.Run.ChurnQueries,
+		Sessions:           w.Run.UsesSessions(),
+		SessionMinDuration: w.Run.SessionMinDuration,
+		SessionMaxDuration: w.Run.SessionMaxDuration,
+		ThinkTimeMin:       w.Run.ThinkTimeMin,
+		ThinkTimeMax:       w.Run.ThinkTimeMax,
 		MaintainSize:       maintainSize,
 		TargetSize:         targetSize,
+		TargetQPS:          w.Run.TargetQPS,
+		TimeScale:          w.Run.TimeScale,
+		StartTime:          startTime,
+		Seed:               w.Run.Seed,
+		Phases:             phases,
+		Reconnect: workload.ReconnectPolicy{
+			MaxAttempts:    w.Run.Reconnect.MaxAttempts,
+			InitialBackoff: time.Duration(w.Run.Reconnect.InitialBackoff) * time.Millisecond,
+			MaxBackoff:     time.Duration(w.Run.Reconnect.MaxBackoff) * time.Millisecond,
+		},
+		Retry: workload.RetryPolicy{
+			SerializationFailure: w.Run.Retry.SerializationFailure,
+			Deadlock:             w.Run.Retry.Deadlock,
+			Connection:           w.Run.Retry.Connection,
+		},
+		Availability:   w.Run.Availability,
+		QueryTimeout:   time.Duration(w.Run.QueryTimeout) * time.Millisecond,
+		QueryTimeouts:  queryTimeouts,
+		Settings:       w.Run.SessionSettings(),
+		ServerStats:    w.Run.ServerStats,
+		TimeSeriesFile: w.OutputPath(w.Run.TimeSeriesFile),
 	})
 	if err != nil {
-		return fmt.Errorf("failed to create executor: %w", err)
+		return nil, fmt.Errorf("failed to create executor: %w", err)
 	}
 
-	// Run until context is cancelled (signal or timeout)
-	if err := executor.Run(ctx); err != nil {
-		if ctx.Err() != nil {
-			// Context was cancelled (shutdown signal or timeout)
-			if ctx.Err() == context.DeadlineExceeded {
-				logging.Info().Msg("Duration limit reached, stopping simulation")
-			} else {
-				logging.Info().Msg("Load simulation stopped")
-			}
-			executor.PrintSummary()
-			return nil
+	return &workloadRun{w: w, executor: executor, metadata: metadata}, nil
+}
+
+// durationMsg describes how long a workload will run for.
+func durationMsg(r *config.RunConfig) string {
+	if r.Duration > 0 {
+		return fmt.Sprintf("%d minutes", r.Duration)
+	}
+	if len(r.Phases) > 0 {
+		var total time.Duration
+		for i := range r.Phases {
+			d, _ := r.Phases[i].PhaseDuration()
+			total += d
+		}
+		return fmt.Sprintf("%s in %d phases", total, len(r.Phases))
+	}
+	return "indefinitely"
+}
+
+// phaseSchedule converts the configured load phases for the executor.
+func phaseSchedule(r *config.RunConfig) ([]workload.Phase, error) {
+	var phases []workload.Phase
+	for i := range r.Phases {
+		p := &r.Phases[i]
+		d, err := p.PhaseDuration()
+		if err != nil {
+			return nil, err
 		}
-		return fmt.Errorf("executor error: %w", err)
+		phases = append(phases, workload.Phase{
+			Name:        p.Name,
+			Duration:    d,
+			Connections: p.Connections,
+			Ramp:        p.Ramp,
+		})
 	}
+	return phases, nil
+}
+
+// writeReport writes the workload's run summary report if a report file
+// was requested.
+func (run *workloadRun) writeReport() error {
+	path := run.w.OutputPath(run.w.Run.ReportFile)
+	if path == "" {
+		return nil
+	}
+
+	r := run.executor.Report()
+	r.Config = run.w.Run
+	r.Metadata = run.metadata
 
-	// Normal completion (shouldn't happen unless duration was set)
-	logging.Info().Msg("Load simulation completed")
-	executor.PrintSummary()
+	if err := report.Write(path, r); err != nil {
+		return err
+	}
+
+	logging.Info().
+		Str("file", path).
+		Msg("Run report written")
 	return nil
 }
@@ -16,8 +16,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"time"
 
 	"github.com/spf13/viper"
+
+	"github.com/pgEdge/pgedge-loadgen/internal/workload/profiles"
 )
 
 // Config holds all configuration for pgedge-loadgen.
@@ -28,14 +32,41 @@ type Config struct {
 	// App is the application type to use.
 	App string `mapstructure:"app"`
 
+	// Schema is the PostgreSQL schema holding the app's tables and
+	// metadata. Empty uses the connection's default search_path.
+	Schema string `mapstructure:"schema"`
+
 	// LogLevel controls logging verbosity (debug, info, warn, error).
 	LogLevel string `mapstructure:"log_level"`
 
+	// LogFormat is the log output format: console (human-readable) or
+	// json (one JSON object per line).
+	LogFormat string `mapstructure:"log_format"`
+
+	// LogFile is the path of a file to log to instead of stderr. Empty
+	// (default) logs to stderr.
+	LogFile string `mapstructure:"log_file"`
+
+	// LogMaxSize is the size in megabytes at which the log file is
+	// rotated (0 = never).
+	LogMaxSize int `mapstructure:"log_max_size"`
+
+	// LogMaxBackups is the number of rotated log files kept.
+	LogMaxBackups int `mapstructure:"log_max_backups"`
+
 	// Init holds configuration for the init subcommand.
 	Init InitConfig `mapstructure:"init"`
 
 	// Run holds configuration for the run subcommand.
 	Run RunConfig `mapstructure:"run"`
+
+	// Profiles holds custom usage profile definitions, which are
+	// registered alongside the built-in profiles.
+	Profiles []profiles.Definition `mapstructure:"profiles"`
+
+	// Workloads initializes and runs several apps against one database,
+	// each in its own PostgreSQL schema. It replaces App.
+	Workloads []WorkloadConfig `mapstructure:"workloads"`
 }
 
 // InitConfig holds configuration for database initialization.
@@ -61,47 +92,240 @@ type InitConfig struct {
 
 	// Concurrency is the number of concurrent connections for init.
 	Concurrency int `mapstructure:"concurrency"`
+
+	// Seed seeds the data generator so that the same seed and size produce
+	// the same data. 0 (default) picks a random seed.
+	Seed uint64 `mapstructure:"seed"`
 }
 
 // RunConfig holds configuration for load generation.
 type RunConfig struct {
 	// Connections is the maximum number of database connections.
-	Connections int `mapstructure:"connections"`
+	Connections int `mapstructure:"connections" json:"connections"`
 
 	// Profile is the usage profile to simulate.
-	Profile string `mapstructure:"profile"`
+	Profile string `mapstructure:"profile" json:"profile"`
 
 	// Timezone is the timezone for profile calculations.
-	Timezone string `mapstructure:"timezone"`
+	Timezone string `mapstructure:"timezone" json:"timezone"`
 
 	// ReportInterval is how often to print statistics (in seconds).
-	ReportInterval int `mapstructure:"report_interval"`
+	ReportInterval int `mapstructure:"report_interval" json:"report_interval"`
 
 	// Duration is how long to run in minutes (0 = indefinite).
-	Duration int `mapstructure:"duration"`
+	Duration int `mapstructure:"duration" json:"duration"`
 
-	// ConnectionMode controls how connections are used: "pool" or "session".
+	// ConnectionMode controls how connections are used: "pool", "session"
+	// or "churn".
 	// Pool mode (default): connections are shared and reused rapidly (web apps).
 	// Session mode: workers simulate user sessions with think time (desktop apps).
-	ConnectionMode string `mapstructure:"connection_mode"`
+	// Churn mode: workers reconnect for each session or every ChurnQueries
+	// queries (short-lived clients such as serverless functions).
+	ConnectionMode string `mapstructure:"connection_mode" json:"connection_mode"`
+
+	// ChurnQueries is the number of queries run on each connection in churn
+	// mode. 0 (default) opens a new connection for each user session, with
+	// the session settings below.
+	ChurnQueries int `mapstructure:"churn_queries" json:"churn_queries"`
 
 	// SessionMinDuration is the minimum session duration in seconds (session mode only).
-	SessionMinDuration int `mapstructure:"session_min_duration"`
+	SessionMinDuration int `mapstructure:"session_min_duration" json:"session_min_duration"`
 
 	// SessionMaxDuration is the maximum session duration in seconds (session mode only).
-	SessionMaxDuration int `mapstructure:"session_max_duration"`
+	SessionMaxDuration int `mapstructure:"session_max_duration" json:"session_max_duration"`
 
 	// ThinkTimeMin is the minimum think time between queries in milliseconds (session mode only).
-	ThinkTimeMin int `mapstructure:"think_time_min"`
+	ThinkTimeMin int `mapstructure:"think_time_min" json:"think_time_min"`
 
 	// ThinkTimeMax is the maximum think time between queries in milliseconds (session mode only).
-	ThinkTimeMax int `mapstructure:"think_time_max"`
+	ThinkTimeMax int `mapstructure:"think_time_max" json:"think_time_max"`
+
+	// PoolSize is the number of connections in a connection pool shared by
+	// the workers (pool mode only). 0 (default) gives each worker its own
+	// connection.
+	PoolSize int `mapstructure:"pool_size" json:"pool_size"`
+
+	// TargetQPS enables open-loop target rate mode: queries are issued at this
+	// rate (scaled by the profile activity level) regardless of server latency.
+	// 0 (default) runs workers closed-loop. Pool mode only.
+	TargetQPS float64 `mapstructure:"target_qps" json:"target_qps"`
+
+	// MetricsListen is the address (e.g. ":9102") on which to serve
+	// Prometheus metrics at /metrics. Empty (default) disables the endpoint.
+	MetricsListen string `mapstructure:"metrics_listen" json:"metrics_listen"`
+
+	// ReportFile is the path of a machine-readable run summary written when
+	// the run ends. The format is CSV if the file name ends in .csv and JSON
+	// otherwise. Empty (default) disables the report.
+	ReportFile string `mapstructure:"report_file" json:"report_file"`
+
+	// TimeSeriesFile is the path of a CSV file to which each report
+	// interval's statistics are written as a row as the run progresses.
+	// Empty (default) disables the file.
+	TimeSeriesFile string `mapstructure:"timeseries_file" json:"timeseries_file"`
+
+	// QueryWeights overrides the relative weights of the app's queries by
+	// query name. A weight of 0 disables a query.
+	QueryWeights map[string]int `mapstructure:"query_weights" json:"query_weights,omitempty"`
+
+	// QueryTimeout is the client-side timeout of each query in
+	// milliseconds. A query still running when it expires is cancelled and
+	// counted as a timeout rather than an error. 0 (default) disables it.
+	QueryTimeout int `mapstructure:"query_timeout" json:"query_timeout"`
+
+	// QueryTimeouts overrides QueryTimeout by query name, in milliseconds.
+	// A timeout of 0 disables the timeout for a query.
+	QueryTimeouts map[string]int `mapstructure:"query_timeouts" json:"query_timeouts,omitempty"`
+
+	// StatementTimeout sets the server's statement_timeout for the workers'
+	// connections in milliseconds. 0 (default) keeps the server setting.
+	StatementTimeout int `mapstructure:"statement_timeout" json:"statement_timeout"`
+
+	// LockTimeout sets the server's lock_timeout for the workers'
+	// connections in milliseconds. 0 (default) keeps the server setting.
+	LockTimeout int `mapstructure:"lock_timeout" json:"lock_timeout"`
+
+	// TimeScale is the speed at which the usage profile is played back
+	// relative to real time, e.g. 60 plays an hour of the profile per
+	// minute. Default is 1 (real time); 0 is treated as 1.
+	TimeScale float64 `mapstructure:"time_scale" json:"time_scale"`
+
+	// StartTime is the profile time at which playback starts, in the
+	// profile timezone unless an offset is given (e.g. "2026-01-05 06:00").
+	// Empty (default) starts at the current time.
+	StartTime string `mapstructure:"start_time" json:"start_time,omitempty"`
+
+	// Seed seeds the workers' query parameters and session timings, each
+	// worker using the seed plus its worker ID. 0 (default) picks a random
+	// seed.
+	Seed uint64 `mapstructure:"seed" json:"seed"`
+
+	// Phases is a load schedule that starts and retires workers over the
+	// run, e.g. to ramp up, hold and step the number of connections. When
+	// set, it replaces Connections and the run ends after the last phase.
+	Phases []PhaseConfig `mapstructure:"phases" json:"phases,omitempty"`
+
+	// Reconnect controls how workers re-establish lost connections.
+	Reconnect ReconnectConfig `mapstructure:"reconnect" json:"reconnect"`
+
+	// Retry sets how many times a failed query is retried, by error class.
+	Retry RetryConfig `mapstructure:"retry" json:"retry"`
+
+	// Availability enables availability mode, which detects outages (e.g.
+	// during a failover) and reports the downtime, failed queries and time
+	// to recover for each database node.
+	Availability bool `mapstructure:"availability" json:"availability"`
+
+	// ServerStats samples the server's statistics (pg_stat_database, the
+	// app's tables, pg_stat_statements if available, WAL generation and
+	// replication lag) every report interval over a separate connection,
+	// and reports the changes alongside the client-side statistics.
+	ServerStats bool `mapstructure:"server_stats" json:"server_stats"`
+
+	// TUI shows a live dashboard in the terminal instead of the log output,
+	// if stdout is a terminal.
+	TUI bool `mapstructure:"tui" json:"tui"`
+}
+
+// ReconnectConfig controls how workers re-establish lost connections, e.g.
+// during a server restart or failover. The delay between attempts starts
+// at InitialBackoff and doubles up to MaxBackoff.
+type ReconnectConfig struct {
+	// MaxAttempts is the number of consecutive failed attempts after which
+	// a worker gives up and stops. 0 (default) retries indefinitely.
+	MaxAttempts int `mapstructure:"max_attempts" json:"max_attempts"`
+
+	// InitialBackoff is the delay after the first failed attempt in
+	// milliseconds. Default is 100; 0 is treated as the default.
+	InitialBackoff int `mapstructure:"initial_backoff" json:"initial_backoff"`
+
+	// MaxBackoff is the maximum delay between attempts in milliseconds.
+	// Default is 10000; 0 is treated as the default.
+	MaxBackoff int `mapstructure:"max_backoff" json:"max_backoff"`
+}
+
+// RetryConfig sets how many times a failed query is retried, by error
+// class. 0 (default) does not retry.
+type RetryConfig struct {
+	// SerializationFailure is the number of retries after a serialization
+	// failure (SQLSTATE 40001).
+	SerializationFailure int `mapstructure:"serialization_failure" json:"serialization_failure"`
+
+	// Deadlock is the number of retries after a deadlock (SQLSTATE 40P01).
+	Deadlock int `mapstructure:"deadlock" json:"deadlock"`
+
+	// Connection is the number of retries, after reconnecting, of a query
+	// whose connection was lost.
+	Connection int `mapstructure:"connection" json:"connection"`
+}
+
+// PhaseConfig is a stage of the run's load schedule.
+type PhaseConfig struct {
+	// Name identifies the phase in the statistics output. Defaults to
+	// "phase N".
+	Name string `mapstructure:"name" json:"name,omitempty"`
+
+	// Duration is how long the phase lasts, e.g. "10m" or "1h".
+	Duration string `mapstructure:"duration" json:"duration"`
+
+	// Connections is the number of workers at the end of the phase.
+	Connections int `mapstructure:"connections" json:"connections"`
+
+	// Ramp changes the number of workers linearly from the previous
+	// phase's count over the phase. Otherwise the count steps to
+	// Connections at the start of the phase.
+	Ramp bool `mapstructure:"ramp" json:"ramp,omitempty"`
+}
+
+// PhaseDuration parses the phase Duration.
+func (p *PhaseConfig) PhaseDuration() (time.Duration, error) {
+	d, err := time.ParseDuration(p.Duration)
+	if err != nil {
+		return 0, fmt.Errorf("invalid phase duration '%s' (expected e.g. 10m or 1h)", p.Duration)
+	}
+	return d, nil
+}
+
+// startTimeLayouts are the accepted formats for RunConfig.StartTime.
+var startTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ProfileStartTime parses StartTime in the configured timezone. It returns
+// the zero time if StartTime is empty.
+func (r *RunConfig) ProfileStartTime() (time.Time, error) {
+	if r.StartTime == "" {
+		return time.Time{}, nil
+	}
+
+	loc := time.Local
+	if r.Timezone != "" && r.Timezone != "Local" {
+		var err error
+		if loc, err = time.LoadLocation(r.Timezone); err != nil {
+			return time.Time{}, fmt.Errorf("invalid timezone: %w", err)
+		}
+	}
+
+	for _, layout := range startTimeLayouts {
+		if t, err := time.ParseInLocation(layout, r.StartTime, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid start_time '%s' (expected e.g. 2006-01-02 15:04)", r.StartTime)
 }
 
 // DefaultConfig returns a Config with default values.
 func DefaultConfig() *Config {
 	return &Config{
-		LogLevel: "info",
+		LogLevel:      "info",
+		LogFormat:     "console",
+		LogMaxSize:    100,
+		LogMaxBackups: 5,
 		Init: InitConfig{
 			Size:                "1GB",
 			EmbeddingMode:       "random",
@@ -119,6 +343,11 @@ func DefaultConfig() *Config {
 			SessionMaxDuration: 1800, // 30 minutes
 			ThinkTimeMin:       1000, // 1 second
 			ThinkTimeMax:       5000, // 5 seconds
+			TimeScale:          1,
+			Reconnect: ReconnectConfig{
+				InitialBackoff: 100,   // 100 milliseconds
+				MaxBackoff:     10000, // 10 seconds
+			},
 		},
 	}
 }
@@ -166,14 +395,31 @@ func Load(configFile string) (*Config, error) {
 	return cfg, nil
 }
 
+// ValidateLogging checks the logging settings.
+func (c *Config) ValidateLogging() error {
+	if c.LogFormat != "console" && c.LogFormat != "json" {
+		return fmt.Errorf("log_format must be 'console' or 'json'")
+	}
+	if c.LogMaxSize < 0 || c.LogMaxBackups < 0 {
+		return fmt.Errorf("log_max_size and log_max_backups must be non-negative")
+	}
+	return nil
+}
+
 // Validate checks that required configuration is present.
 func (c *Config) Validate() error {
 	if c.Connection == "" {
 		return fmt.Errorf("connection string is required")
 	}
+	if len(c.Workloads) > 0 {
+		return c.validateWorkloads()
+	}
 	if c.App == "" {
 		return fmt.Errorf("app type is required")
 	}
+	if c.Schema != "" {
+		return validateSchema(c.Schema)
+	}
 	return nil
 }
 
@@ -182,10 +428,20 @@ func (c *Config) ValidateInit() error {
 	if err := c.Validate(); err != nil {
 		return err
 	}
-	if c.Init.Size == "" {
+	for _, w := range c.ResolveWorkloads() {
+		if err := w.WrapError(w.Init.validate()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate checks the init settings.
+func (i *InitConfig) validate() error {
+	if i.Size == "" {
 		return fmt.Errorf("target size is required for init")
 	}
-	if c.Init.Concurrency < 1 {
+	if i.Concurrency < 1 {
 		return fmt.Errorf("concurrency must be at least 1")
 	}
 	return nil
@@ -196,25 +452,156 @@ func (c *Config) ValidateRun() error {
 	if err := c.Validate(); err != nil {
 		return err
 	}
-	if c.Run.Connections < 1 {
+	for _, w := range c.ResolveWorkloads() {
+		if err := w.WrapError(w.Run.validate()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate checks the run settings.
+func (r *RunConfig) validate() error {
+	if r.Connections < 1 {
 		return fmt.Errorf("connections must be at least 1")
 	}
-	if c.Run.ConnectionMode != "pool" && c.Run.ConnectionMode != "session" {
-		return fmt.Errorf("connection_mode must be 'pool' or 'session'")
+	if r.ConnectionMode != "pool" && r.ConnectionMode != "session" && r.ConnectionMode != "churn" {
+		return fmt.Errorf("connection_mode must be 'pool', 'session' or 'churn'")
+	}
+	if r.ChurnQueries < 0 {
+		return fmt.Errorf("churn_queries must be non-negative")
+	}
+	if r.TargetQPS < 0 {
+		return fmt.Errorf("target_qps must be non-negative")
+	}
+	if r.TargetQPS > 0 && r.ConnectionMode != "pool" {
+		return fmt.Errorf("target_qps is only supported in pool connection mode")
+	}
+	if r.PoolSize < 0 {
+		return fmt.Errorf("pool_size must be non-negative")
+	}
+	if r.PoolSize > 0 && r.ConnectionMode != "pool" {
+		return fmt.Errorf("pool_size is only supported in pool connection mode")
+	}
+	if r.TimeScale < 0 {
+		return fmt.Errorf("time_scale must be non-negative")
+	}
+	if _, err := r.ProfileStartTime(); err != nil {
+		return err
+	}
+	if err := r.validatePhases(); err != nil {
+		return err
+	}
+	if r.PoolSize > 0 && r.PoolSize >= r.peakConnections() {
+		return fmt.Errorf("pool_size must be less than connections; " +
+			"set it to 0 to give each client its own connection")
+	}
+	for name, weight := range r.QueryWeights {
+		if weight < 0 {
+			return fmt.Errorf("query_weights: weight for '%s' must be non-negative", name)
+		}
+	}
+	if r.QueryTimeout < 0 {
+		return fmt.Errorf("query_timeout must be non-negative")
+	}
+	for name, timeout := range r.QueryTimeouts {
+		if timeout < 0 {
+			return fmt.Errorf("query_timeouts: timeout for '%s' must be non-negative", name)
+		}
+	}
+	if r.StatementTimeout < 0 || r.LockTimeout < 0 {
+		return fmt.Errorf("statement_timeout and lock_timeout must be non-negative")
+	}
+	if r.TimeSeriesFile != "" && r.ReportInterval < 1 {
+		return fmt.Errorf("timeseries_file requires a report_interval of at least 1 second")
 	}
-	if c.Run.ConnectionMode == "session" {
-		if c.Run.SessionMinDuration < 1 {
+	if r.Reconnect.MaxAttempts < 0 || r.Reconnect.InitialBackoff < 0 || r.Reconnect.MaxBackoff < 0 {
+		return fmt.Errorf("reconnect settings must be non-negative")
+	}
+	if r.Reconnect.MaxBackoff > 0 && r.Reconnect.MaxBackoff < r.Reconnect.InitialBackoff {
+		return fmt.Errorf("reconnect.max_backoff must be >= reconnect.initial_backoff")
+	}
+	if r.Retry.SerializationFailure < 0 || r.Retry.Deadlock < 0 || r.Retry.Connection < 0 {
+		return fmt.Errorf("retry counts must be non-negative")
+	}
+	if r.UsesSessions() {
+		if r.SessionMinDuration < 1 {
 			return fmt.Errorf("session_min_duration must be at least 1 second")
 		}
-		if c.Run.SessionMaxDuration < c.Run.SessionMinDuration {
+		if r.SessionMaxDuration < r.SessionMinDuration {
 			return fmt.Errorf("session_max_duration must be >= session_min_duration")
 		}
-		if c.Run.ThinkTimeMin < 0 {
+		if r.ThinkTimeMin < 0 {
 			return fmt.Errorf("think_time_min must be non-negative")
 		}
-		if c.Run.ThinkTimeMax < c.Run.ThinkTimeMin {
+		if r.ThinkTimeMax < r.ThinkTimeMin {
 			return fmt.Errorf("think_time_max must be >= think_time_min")
 		}
 	}
 	return nil
 }
+
+// peakConnections returns the largest number of clients during the run:
+// connections, or the largest phase's connections if there is a phase
+// schedule.
+func (r *RunConfig) peakConnections() int {
+	if len(r.Phases) == 0 {
+		return r.Connections
+	}
+	peak := 0
+	for _, p := range r.Phases {
+		peak = max(peak, p.Connections)
+	}
+	return peak
+}
+
+// UsesSessions reports whether workers simulate user sessions: in session
+// mode, and in churn mode with a connection per session.
+func (r *RunConfig) UsesSessions() bool {
+	return r.ConnectionMode == "session" ||
+		(r.ConnectionMode == "churn" && r.ChurnQueries == 0)
+}
+
+// SessionSettings returns the server settings for the workers'
+// connections, or nil if there are none.
+func (r *RunConfig) SessionSettings() map[string]string {
+	var settings map[string]string
+	set := func(name string, ms int) {
+		if ms > 0 {
+			if settings == nil {
+				settings = make(map[string]string)
+			}
+			settings[name] = strconv.Itoa(ms)
+		}
+	}
+	set("statement_timeout", r.StatementTimeout)
+	set("lock_timeout", r.LockTimeout)
+	return settings
+}
+
+// validatePhases checks the load phase schedule.
+func (r *RunConfig) validatePhases() error {
+	if len(r.Phases) == 0 {
+		return nil
+	}
+
+	peak := 0
+	for i := range r.Phases {
+		p := &r.Phases[i]
+		d, err := p.PhaseDuration()
+		if err != nil {
+			return fmt.Errorf("phases[%d]: %w", i, err)
+		}
+		if d < time.Second {
+			return fmt.Errorf("phases[%d]: duration must be at least 1s", i)
+		}
+		if p.Connections < 0 {
+			return fmt.Errorf("phases[%d]: connections must be non-negative", i)
+		}
+		peak = max(peak, p.Connections)
+	}
+	if peak < 1 {
+		return fmt.Errorf("phases: at least one phase must have connections")
+	}
+	return nil
+}
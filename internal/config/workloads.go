@@ -0,0 +1,197 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package config
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"strings"
+)
+
+// WorkloadConfig is an app in a mixed workload. Settings that are not set
+// are taken from the init and run sections.
+type WorkloadConfig struct {
+	// App is the application type.
+	App string `mapstructure:"app"`
+
+	// Schema is the PostgreSQL schema holding the app's tables and
+	// metadata. Defaults to the app name.
+	Schema string `mapstructure:"schema"`
+
+	// Size overrides init.size.
+	Size string `mapstructure:"size"`
+
+	// Connections overrides run.connections.
+	Connections int `mapstructure:"connections"`
+
+	// Profile overrides run.profile.
+	Profile string `mapstructure:"profile"`
+
+	// ConnectionMode overrides run.connection_mode.
+	ConnectionMode string `mapstructure:"connection_mode"`
+
+	// ChurnQueries overrides run.churn_queries.
+	ChurnQueries int `mapstructure:"churn_queries"`
+
+	// PoolSize overrides run.pool_size.
+	PoolSize int `mapstructure:"pool_size"`
+
+	// TargetQPS overrides run.target_qps.
+	TargetQPS float64 `mapstructure:"target_qps"`
+
+	// QueryWeights overrides run.query_weights.
+	QueryWeights map[string]int `mapstructure:"query_weights"`
+
+	// QueryTimeouts overrides run.query_timeouts.
+	QueryTimeouts map[string]int `mapstructure:"query_timeouts"`
+
+	// Phases overrides run.phases.
+	Phases []PhaseConfig `mapstructure:"phases"`
+}
+
+// Workload is an app to initialize or run, with its effective settings.
+type Workload struct {
+	// App is the application type.
+	App string
+
+	// Schema is the PostgreSQL schema holding the app's tables, or empty
+	// to use the connection's default search_path.
+	Schema string
+
+	// Mixed is set for the apps of a workloads section, whose output files
+	// are written separately for each app.
+	Mixed bool
+
+	// Init and Run are the effective init and run settings.
+	Init InitConfig
+	Run  RunConfig
+}
+
+// ResolveWorkloads returns the workloads to initialize or run. If
+// Workloads is empty, this is App in Schema. Each app of a mixed workload
+// gets its own run seed, so that copies of an app issue different queries.
+func (c *Config) ResolveWorkloads() []Workload {
+	if len(c.Workloads) == 0 {
+		return []Workload{{App: c.App, Schema: c.Schema, Init: c.Init, Run: c.Run}}
+	}
+
+	workloads := make([]Workload, 0, len(c.Workloads))
+	for i, wc := range c.Workloads {
+		w := Workload{
+			App:    wc.App,
+			Schema: wc.Schema,
+			Mixed:  true,
+			Init:   c.Init,
+			Run:    c.Run,
+		}
+		if w.Schema == "" {
+			w.Schema = wc.App
+		}
+		if c.Run.Seed != 0 {
+			w.Run.Seed = workloadSeed(c.Run.Seed, i)
+		}
+		if wc.Size != "" {
+			w.Init.Size = wc.Size
+		}
+		if wc.Connections > 0 {
+			w.Run.Connections = wc.Connections
+		}
+		if wc.Profile != "" {
+			w.Run.Profile = wc.Profile
+		}
+		if wc.ConnectionMode != "" {
+			w.Run.ConnectionMode = wc.ConnectionMode
+		}
+		if wc.ChurnQueries > 0 {
+			w.Run.ChurnQueries = wc.ChurnQueries
+		}
+		if wc.PoolSize > 0 {
+			w.Run.PoolSize = wc.PoolSize
+		}
+		if wc.TargetQPS > 0 {
+			w.Run.TargetQPS = wc.TargetQPS
+		}
+		if len(wc.QueryWeights) > 0 {
+			w.Run.QueryWeights = maps.Clone(wc.QueryWeights)
+		}
+		if len(wc.QueryTimeouts) > 0 {
+			w.Run.QueryTimeouts = maps.Clone(wc.QueryTimeouts)
+		}
+		if len(wc.Phases) > 0 {
+			w.Run.Phases = wc.Phases
+		}
+		workloads = append(workloads, w)
+	}
+	return workloads
+}
+
+// WrapError adds the workload's schema, if it has one, to an error.
+func (w *Workload) WrapError(err error) error {
+	if err == nil || w.Schema == "" {
+		return err
+	}
+	return fmt.Errorf("schema '%s': %w", w.Schema, err)
+}
+
+// workloadSeed returns the run seed of the i-th app of a mixed workload.
+// The seeds are far enough apart that the apps' workers, which add their
+// worker ID, never share a seed.
+func workloadSeed(seed uint64, i int) uint64 {
+	return seed + uint64(i)<<32
+}
+
+// OutputPath returns the path of the workload's copy of an output file. The
+// file name of an app in a mixed workload has its schema added, e.g.
+// summary-wholesale.json; other paths are returned unchanged.
+func (w *Workload) OutputPath(path string) string {
+	if path == "" || !w.Mixed {
+		return path
+	}
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-" + w.Schema + ext
+}
+
+// validateWorkloads checks the mixed workload definitions.
+func (c *Config) validateWorkloads() error {
+	if c.App != "" {
+		return fmt.Errorf("app and workloads cannot both be set")
+	}
+	if c.Schema != "" {
+		return fmt.Errorf("schema and workloads cannot both be set; " +
+			"set the schema of each workload instead")
+	}
+
+	schemas := make(map[string]bool)
+	for i, w := range c.ResolveWorkloads() {
+		if w.App == "" {
+			return fmt.Errorf("workloads[%d]: app is required", i)
+		}
+		if err := validateSchema(w.Schema); err != nil {
+			return fmt.Errorf("workloads[%d]: %w", i, err)
+		}
+		if schemas[w.Schema] {
+			return fmt.Errorf("workloads[%d]: schema '%s' is used more than once", i, w.Schema)
+		}
+		schemas[w.Schema] = true
+	}
+	return nil
+}
+
+// validateSchema checks that a schema can be created and dropped by
+// loadgen. The public schema and the system schemas are not allowed, as
+// init --drop-existing drops the whole schema.
+func validateSchema(schema string) error {
+	if schema == "public" || schema == "information_schema" ||
+		strings.HasPrefix(schema, "pg_") {
+		return fmt.Errorf("schema '%s' is reserved; use another schema name", schema)
+	}
+	return nil
+}
@@ -401,6 +401,14 @@ func TestFakerRandomString(t *testing.T) {
 	}
 }
 
+func TestFakerRandomStringSeeded(t *testing.T) {
+	f1 := NewFakerWithSeed(42)
+	f2 := NewFakerWithSeed(42)
+	if f1.RandomString(32, "ABC123") != f2.RandomString(32, "ABC123") {
+		t.Error("Same seed produced different random strings")
+	}
+}
+
 func containsRune(s string, r rune) bool {
 	for _, c := range s {
 		if c == r {
@@ -13,9 +13,13 @@ package db
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgconn/ctxwatch"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/pgEdge/pgedge-loadgen/internal/logging"
@@ -24,6 +28,10 @@ import (
 const (
 	// AppNamePrefix is the prefix used for all application names.
 	AppNamePrefix = "pgedge-loadgen"
+
+	// cancelDeadlineDelay is how long to wait for the server to cancel a
+	// query whose context is done before the connection is closed.
+	cancelDeadlineDelay = 5 * time.Second
 )
 
 // DefaultPoolConfig returns default connection pool configuration.
@@ -41,8 +49,12 @@ func DefaultPoolConfig() *pgxpool.Config {
 }
 
 // Connect establishes a connection pool to the PostgreSQL database
-// with a specified maximum number of connections.
-func Connect(ctx context.Context, connString string, maxConns int32, appNameSuffix string) (*pgxpool.Pool, error) {
+// with a specified maximum number of connections. If schema is not empty,
+// unqualified table names resolve in that schema (see SearchPath).
+// settings are session settings for the connections, such as
+// statement_timeout.
+func Connect(ctx context.Context, connString string, schema string, settings map[string]string,
+	maxConns int32, appNameSuffix string) (*pgxpool.Pool, error) {
 	config, err := pgxpool.ParseConfig(connString)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse connection string: %w", err)
@@ -65,6 +77,8 @@ func Connect(ctx context.Context, connString string, maxConns int32, appNameSuff
 		config.ConnConfig.RuntimeParams = make(map[string]string)
 	}
 	config.ConnConfig.RuntimeParams["application_name"] = appName
+	applySessionSettings(&config.ConnConfig.Config, schema, settings)
+	cancelQueriesOnContextDone(&config.ConnConfig.Config)
 
 	logging.Debug().
 		Str("host", config.ConnConfig.Host).
@@ -72,6 +86,7 @@ func Connect(ctx context.Context, connString string, maxConns int32, appNameSuff
 		Str("database", config.ConnConfig.Database).
 		Int32("max_conns", maxConns).
 		Str("application_name", appName).
+		Str("schema", schema).
 		Msg("Connecting to database")
 
 	pool, err := pgxpool.NewWithConfig(ctx, config)
@@ -96,7 +111,10 @@ func Connect(ctx context.Context, connString string, maxConns int32, appNameSuff
 
 // ConnectSingle establishes a single database connection (not pooled).
 // This is useful for workers that need dedicated connections with unique application names.
-func ConnectSingle(ctx context.Context, connString string, appNameSuffix string) (*pgx.Conn, error) {
+// If schema is not empty, unqualified table names resolve in that schema.
+// settings are session settings for the connection, such as statement_timeout.
+func ConnectSingle(ctx context.Context, connString string, schema string, settings map[string]string,
+	appNameSuffix string) (*pgx.Conn, error) {
 	config, err := pgx.ParseConfig(connString)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse connection string: %w", err)
@@ -111,12 +129,15 @@ func ConnectSingle(ctx context.Context, connString string, appNameSuffix string)
 		config.RuntimeParams = make(map[string]string)
 	}
 	config.RuntimeParams["application_name"] = appName
+	applySessionSettings(&config.Config, schema, settings)
+	cancelQueriesOnContextDone(&config.Config)
 
 	logging.Debug().
 		Str("host", config.Host).
 		Uint16("port", config.Port).
 		Str("database", config.Database).
 		Str("application_name", appName).
+		Str("schema", schema).
 		Msg("Connecting to database")
 
 	conn, err := pgx.ConnectConfig(ctx, config)
@@ -132,3 +153,70 @@ func ConnectSingle(ctx context.Context, connString string, appNameSuffix string)
 
 	return conn, nil
 }
+
+// applySessionSettings sets search_path for the schema, if not empty, and
+// the session settings on each new connection. They are applied with
+// set_config after connecting rather than sent as startup parameters,
+// which connection poolers such as PgBouncer reject unless configured to
+// ignore them.
+func applySessionSettings(config *pgconn.Config, schema string, settings map[string]string) {
+	var params [][][]byte
+	if schema != "" {
+		params = append(params, [][]byte{[]byte("search_path"), []byte(SearchPath(schema))})
+	}
+	for _, name := range slices.Sorted(maps.Keys(settings)) {
+		params = append(params, [][]byte{[]byte(name), []byte(settings[name])})
+	}
+	if len(params) == 0 {
+		return
+	}
+
+	config.AfterConnect = func(ctx context.Context, pgConn *pgconn.PgConn) error {
+		for _, p := range params {
+			result := pgConn.ExecParams(ctx, "SELECT set_config($1, $2, false)",
+				p, nil, nil, nil).Read()
+			if result.Err != nil {
+				return fmt.Errorf("failed to set %s: %w", p[0], result.Err)
+			}
+		}
+		return nil
+	}
+}
+
+// cancelQueriesOnContextDone makes a query whose context is done, e.g.
+// because its timeout expired, be cancelled on the server with a cancel
+// request, rather than the connection being closed while the server keeps
+// running the query.
+func cancelQueriesOnContextDone(config *pgconn.Config) {
+	config.BuildContextWatcherHandler = func(pgConn *pgconn.PgConn) ctxwatch.Handler {
+		return &pgconn.CancelRequestContextWatcherHandler{
+			Conn:          pgConn,
+			DeadlineDelay: cancelDeadlineDelay,
+		}
+	}
+}
+
+// SearchPath returns the search_path used for connections to a schema.
+// The schema comes first so that tables are created and found there, and
+// public follows so that extension types such as pgvector's remain visible.
+func SearchPath(schema string) string {
+	return pgx.Identifier{schema}.Sanitize() + ", public"
+}
+
+// CreateSchema creates a PostgreSQL schema if it does not exist.
+func CreateSchema(ctx context.Context, pool *pgxpool.Pool, schema string) error {
+	_, err := pool.Exec(ctx, "CREATE SCHEMA IF NOT EXISTS "+pgx.Identifier{schema}.Sanitize())
+	if err != nil {
+		return fmt.Errorf("failed to create schema %s: %w", schema, err)
+	}
+	return nil
+}
+
+// DropSchema drops a PostgreSQL schema and everything in it.
+func DropSchema(ctx context.Context, pool *pgxpool.Pool, schema string) error {
+	_, err := pool.Exec(ctx, "DROP SCHEMA IF EXISTS "+pgx.Identifier{schema}.Sanitize()+" CASCADE")
+	if err != nil {
+		return fmt.Errorf("failed to drop schema %s: %w", schema, err)
+	}
+	return nil
+}
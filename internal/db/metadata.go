@@ -12,6 +12,7 @@ package db
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -25,15 +26,27 @@ const metadataTable = "loadgen_metadata"
 
 // createMetadataTableSQL creates the metadata table if it doesn't exist.
 const createMetadataTableSQL = `
-CREATE TABLE IF NOT EXISTS loadgen_metadata (
+CREATE TABLE IF NOT EXISTS %s (
     key   TEXT PRIMARY KEY,
     value TEXT NOT NULL
 )`
 
+// metadataTableName returns the metadata table name, qualified with the
+// schema if one is given. Qualifying the name ensures that the metadata of
+// another schema on the search_path is never used by mistake.
+func metadataTableName(schema string) string {
+	if schema == "" {
+		return metadataTable
+	}
+	return pgx.Identifier{schema, metadataTable}.Sanitize()
+}
+
 // SaveMetadata saves initialization metadata to the database.
-func SaveMetadata(ctx context.Context, pool *pgxpool.Pool, app string, targetSize string) error {
+func SaveMetadata(ctx context.Context, pool *pgxpool.Pool, schema string, app string, targetSize string, seed uint64) error {
+	table := metadataTableName(schema)
+
 	// Create table if it doesn't exist
-	_, err := pool.Exec(ctx, createMetadataTableSQL)
+	_, err := pool.Exec(ctx, fmt.Sprintf(createMetadataTableSQL, table))
 	if err != nil {
 		return fmt.Errorf("failed to create metadata table: %w", err)
 	}
@@ -44,13 +57,14 @@ func SaveMetadata(ctx context.Context, pool *pgxpool.Pool, app string, targetSiz
 		"version":        version.Short(),
 		"initialized_at": time.Now().UTC().Format(time.RFC3339),
 		"target_size":    targetSize,
+		"seed":           strconv.FormatUint(seed, 10),
 	}
 
 	for key, value := range metadata {
-		_, err := pool.Exec(ctx, `
-            INSERT INTO loadgen_metadata (key, value) VALUES ($1, $2)
+		_, err := pool.Exec(ctx, fmt.Sprintf(`
+            INSERT INTO %s (key, value) VALUES ($1, $2)
             ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value
-        `, key, value)
+        `, table), key, value)
 		if err != nil {
 			return fmt.Errorf("failed to save metadata %s: %w", key, err)
 		}
@@ -58,18 +72,20 @@ func SaveMetadata(ctx context.Context, pool *pgxpool.Pool, app string, targetSiz
 
 	logging.Debug().
 		Str("app", app).
+		Str("schema", schema).
 		Str("target_size", targetSize).
+		Uint64("seed", seed).
 		Msg("Saved metadata")
 
 	return nil
 }
 
 // GetMetadataValue retrieves a single metadata value by key.
-func GetMetadataValue(ctx context.Context, pool *pgxpool.Pool, key string) (string, error) {
+func GetMetadataValue(ctx context.Context, pool *pgxpool.Pool, schema string, key string) (string, error) {
 	var value string
-	err := pool.QueryRow(ctx, `
-        SELECT value FROM loadgen_metadata WHERE key = $1
-    `, key).Scan(&value)
+	err := pool.QueryRow(ctx, fmt.Sprintf(`
+        SELECT value FROM %s WHERE key = $1
+    `, metadataTableName(schema)), key).Scan(&value)
 	if err != nil {
 		return "", err
 	}
@@ -77,11 +93,11 @@ func GetMetadataValue(ctx context.Context, pool *pgxpool.Pool, key string) (stri
 }
 
 // GetMetadataValueConn retrieves a single metadata value by key using a single connection.
-func GetMetadataValueConn(ctx context.Context, conn *pgx.Conn, key string) (string, error) {
+func GetMetadataValueConn(ctx context.Context, conn *pgx.Conn, schema string, key string) (string, error) {
 	var value string
-	err := conn.QueryRow(ctx, `
-        SELECT value FROM loadgen_metadata WHERE key = $1
-    `, key).Scan(&value)
+	err := conn.QueryRow(ctx, fmt.Sprintf(`
+        SELECT value FROM %s WHERE key = $1
+    `, metadataTableName(schema)), key).Scan(&value)
 	if err != nil {
 		return "", err
 	}
@@ -89,8 +105,28 @@ func GetMetadataValueConn(ctx context.Context, conn *pgx.Conn, key string) (stri
 }
 
 // GetAllMetadata retrieves all metadata as a map.
-func GetAllMetadata(ctx context.Context, pool *pgxpool.Pool) (map[string]string, error) {
-	rows, err := pool.Query(ctx, `SELECT key, value FROM loadgen_metadata`)
+func GetAllMetadata(ctx context.Context, pool *pgxpool.Pool, schema string) (map[string]string, error) {
+	rows, err := pool.Query(ctx, fmt.Sprintf(`SELECT key, value FROM %s`, metadataTableName(schema)))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	metadata := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+		metadata[key] = value
+	}
+
+	return metadata, rows.Err()
+}
+
+// GetAllMetadataConn retrieves all metadata as a map using a single connection.
+func GetAllMetadataConn(ctx context.Context, conn *pgx.Conn, schema string) (map[string]string, error) {
+	rows, err := conn.Query(ctx, fmt.Sprintf(`SELECT key, value FROM %s`, metadataTableName(schema)))
 	if err != nil {
 		return nil, err
 	}
@@ -109,19 +145,16 @@ func GetAllMetadata(ctx context.Context, pool *pgxpool.Pool) (map[string]string,
 }
 
 // DropMetadata drops the metadata table.
-func DropMetadata(ctx context.Context, pool *pgxpool.Pool) error {
-	_, err := pool.Exec(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %s", metadataTable))
+func DropMetadata(ctx context.Context, pool *pgxpool.Pool, schema string) error {
+	_, err := pool.Exec(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %s", metadataTableName(schema)))
 	return err
 }
 
 // MetadataExists checks if the metadata table exists.
-func MetadataExists(ctx context.Context, pool *pgxpool.Pool) (bool, error) {
+func MetadataExists(ctx context.Context, pool *pgxpool.Pool, schema string) (bool, error) {
 	var exists bool
 	err := pool.QueryRow(ctx, `
-        SELECT EXISTS (
-            SELECT FROM information_schema.tables
-            WHERE table_name = $1
-        )
-    `, metadataTable).Scan(&exists)
+        SELECT to_regclass($1) IS NOT NULL
+    `, metadataTableName(schema)).Scan(&exists)
 	return exists, err
 }
@@ -0,0 +1,204 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// statementTextLength is the number of characters of a statement's text
+// that are kept, after collapsing whitespace.
+const statementTextLength = 200
+
+// ServerSample is a snapshot of the server's cumulative statistics. The
+// database counters are from pg_stat_database for the current database.
+type ServerSample struct {
+	Time time.Time
+
+	Commits        int64
+	Rollbacks      int64
+	BlocksRead     int64
+	BlocksHit      int64
+	TuplesInserted int64
+	TuplesUpdated  int64
+	TuplesDeleted  int64
+	Deadlocks      int64
+	TempBytes      int64
+
+	// WALPosition is the current WAL position in bytes, or -1 on a standby.
+	WALPosition int64
+
+	// Tables holds the statistics of the tables in the schema, by name.
+	Tables map[string]TableCounters
+
+	// Replicas holds the replication status of the standbys and logical
+	// replication clients connected to the server.
+	Replicas []ReplicaStatus
+}
+
+// TableCounters holds a table's statistics from pg_stat_user_tables.
+type TableCounters struct {
+	SeqScans   int64
+	IndexScans int64
+	Inserted   int64
+	Updated    int64
+	HotUpdated int64
+	Deleted    int64
+	LiveTuples int64
+	DeadTuples int64
+	Vacuums    int64 // manual and autovacuum runs
+}
+
+// ReplicaStatus is a replication connection from pg_stat_replication.
+type ReplicaStatus struct {
+	Name       string // application_name of the replication connection
+	Address    string
+	LagBytes   int64   // WAL not yet replayed by the replica
+	LagSeconds float64 // replay lag reported by the replica
+}
+
+// StatementCounters holds a statement's statistics from
+// pg_stat_statements.
+type StatementCounters struct {
+	Query       string
+	Calls       int64
+	TotalTimeMs float64
+	Rows        int64
+}
+
+// SampleServerStats reads the server's cumulative statistics for the
+// current database, and for the tables in schema (the connection's current
+// schema if empty).
+func SampleServerStats(ctx context.Context, conn *pgx.Conn, schema string) (*ServerSample, error) {
+	s := &ServerSample{Time: time.Now()}
+
+	var walPosition *int64
+	err := conn.QueryRow(ctx, `
+        SELECT xact_commit, xact_rollback, blks_read, blks_hit,
+               tup_inserted, tup_updated, tup_deleted, deadlocks, temp_bytes,
+               CASE WHEN pg_is_in_recovery() THEN NULL
+                    ELSE pg_wal_lsn_diff(pg_current_wal_lsn(), '0/0')::bigint
+               END
+        FROM pg_stat_database
+        WHERE datname = current_database()
+    `).Scan(&s.Commits, &s.Rollbacks, &s.BlocksRead, &s.BlocksHit,
+		&s.TuplesInserted, &s.TuplesUpdated, &s.TuplesDeleted, &s.Deadlocks, &s.TempBytes,
+		&walPosition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pg_stat_database: %w", err)
+	}
+	s.WALPosition = -1
+	if walPosition != nil {
+		s.WALPosition = *walPosition
+	}
+
+	if s.Tables, err = sampleTables(ctx, conn, schema); err != nil {
+		return nil, err
+	}
+	if s.WALPosition >= 0 {
+		if s.Replicas, err = sampleReplicas(ctx, conn); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// sampleTables reads the statistics of the tables in a schema.
+func sampleTables(ctx context.Context, conn *pgx.Conn, schema string) (map[string]TableCounters, error) {
+	rows, err := conn.Query(ctx, `
+        SELECT relname, coalesce(seq_scan, 0), coalesce(idx_scan, 0),
+               n_tup_ins, n_tup_upd, n_tup_hot_upd, n_tup_del,
+               n_live_tup, n_dead_tup, vacuum_count + autovacuum_count
+        FROM pg_stat_user_tables
+        WHERE schemaname = coalesce(nullif($1, ''), current_schema())
+    `, schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pg_stat_user_tables: %w", err)
+	}
+	defer rows.Close()
+
+	tables := make(map[string]TableCounters)
+	for rows.Next() {
+		var name string
+		var t TableCounters
+		if err := rows.Scan(&name, &t.SeqScans, &t.IndexScans,
+			&t.Inserted, &t.Updated, &t.HotUpdated, &t.Deleted,
+			&t.LiveTuples, &t.DeadTuples, &t.Vacuums); err != nil {
+			return nil, err
+		}
+		tables[name] = t
+	}
+	return tables, rows.Err()
+}
+
+// sampleReplicas reads the replication status of the server's replication
+// connections.
+func sampleReplicas(ctx context.Context, conn *pgx.Conn) ([]ReplicaStatus, error) {
+	rows, err := conn.Query(ctx, `
+        SELECT application_name, coalesce(host(client_addr), ''),
+               coalesce(pg_wal_lsn_diff(pg_current_wal_lsn(), replay_lsn), 0)::bigint,
+               coalesce(extract(epoch FROM replay_lag), 0)::float8
+        FROM pg_stat_replication
+        ORDER BY application_name, client_addr
+    `)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pg_stat_replication: %w", err)
+	}
+	defer rows.Close()
+
+	var replicas []ReplicaStatus
+	for rows.Next() {
+		var r ReplicaStatus
+		if err := rows.Scan(&r.Name, &r.Address, &r.LagBytes, &r.LagSeconds); err != nil {
+			return nil, err
+		}
+		replicas = append(replicas, r)
+	}
+	return replicas, rows.Err()
+}
+
+// SampleStatementStats reads the statistics of the current database's
+// statements from pg_stat_statements (PostgreSQL 13 or later), by query
+// ID. It fails if the extension is not installed in the database or not
+// loaded by the server.
+func SampleStatementStats(ctx context.Context, conn *pgx.Conn) (map[int64]StatementCounters, error) {
+	rows, err := conn.Query(ctx, `
+        SELECT queryid, left(regexp_replace(query, '\s+', ' ', 'g'), $1),
+               calls, total_exec_time, rows
+        FROM pg_stat_statements
+        WHERE dbid = (SELECT oid FROM pg_database WHERE datname = current_database())
+          AND queryid IS NOT NULL
+    `, statementTextLength)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pg_stat_statements: %w", err)
+	}
+	defer rows.Close()
+
+	statements := make(map[int64]StatementCounters)
+	for rows.Next() {
+		var id int64
+		var s StatementCounters
+		if err := rows.Scan(&id, &s.Query, &s.Calls, &s.TotalTimeMs, &s.Rows); err != nil {
+			return nil, err
+		}
+		// A statement can appear once for each user; sum them
+		if prev, ok := statements[id]; ok {
+			s.Calls += prev.Calls
+			s.TotalTimeMs += prev.TotalTimeMs
+			s.Rows += prev.Rows
+		}
+		statements[id] = s
+	}
+	return statements, rows.Err()
+}
@@ -13,6 +13,7 @@ package logging
 import (
 	"io"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -21,11 +22,43 @@ import (
 // Logger is the global logger instance.
 var Logger zerolog.Logger
 
+// output is the destination of all loggers derived from Logger, stderr
+// or the log file, which can be redirected while they are in use.
+var output = &switchWriter{w: os.Stderr}
+
+// switchWriter is a writer whose destination can be changed.
+type switchWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *switchWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
+
+// swap sets the destination and returns the previous one.
+func (s *switchWriter) swap(w io.Writer) io.Writer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev := s.w
+	s.w = w
+	return prev
+}
+
 // Config holds logging configuration.
 type Config struct {
 	Level      string
-	Pretty     bool
+	Pretty     bool // human-readable console output instead of JSON
 	TimeFormat string
+
+	// File is the path of a file to log to instead of stderr, which is
+	// rotated when it reaches MaxSize bytes (0 = never), keeping
+	// MaxBackups rotated files.
+	File       string
+	MaxSize    int64
+	MaxBackups int
 }
 
 // DefaultConfig returns default logging configuration.
@@ -37,9 +70,22 @@ func DefaultConfig() Config {
 	}
 }
 
-// Init initializes the global logger with the given configuration.
-func Init(cfg Config) {
-	var output io.Writer = os.Stderr
+// Init initializes the global logger with the given configuration. It
+// fails if the log file cannot be opened.
+func Init(cfg Config) error {
+	var dest io.Writer = os.Stderr
+	if cfg.File != "" {
+		file, err := openRotatingFile(cfg.File, cfg.MaxSize, cfg.MaxBackups)
+		if err != nil {
+			return err
+		}
+		dest = file
+	}
+	if prev, ok := output.swap(dest).(*rotatingFile); ok {
+		prev.Close()
+	}
+
+	var out io.Writer = output
 
 	// Use default time format if not specified
 	timeFormat := cfg.TimeFormat
@@ -48,9 +94,10 @@ func Init(cfg Config) {
 	}
 
 	if cfg.Pretty {
-		output = zerolog.ConsoleWriter{
-			Out:        os.Stderr,
+		out = zerolog.ConsoleWriter{
+			Out:        output,
 			TimeFormat: timeFormat,
+			NoColor:    cfg.File != "",
 		}
 	}
 
@@ -59,11 +106,29 @@ func Init(cfg Config) {
 		level = zerolog.InfoLevel
 	}
 
-	Logger = zerolog.New(output).
+	Logger = zerolog.New(out).
 		Level(level).
 		With().
 		Timestamp().
 		Logger()
+	return nil
+}
+
+// Redirect sends the log output to w instead of stderr, including that of
+// loggers already derived from Logger, until the returned function is
+// called. When logging to a file, the output goes to both the file and w.
+func Redirect(w io.Writer) (restore func()) {
+	output.mu.Lock()
+	defer output.mu.Unlock()
+
+	prev := output.w
+	output.w = w
+	if _, ok := prev.(*rotatingFile); ok {
+		output.w = io.MultiWriter(prev, w)
+	}
+	return func() {
+		output.swap(prev)
+	}
 }
 
 // Debug returns a debug level event.
@@ -92,5 +157,6 @@ func Fatal() *zerolog.Event {
 }
 
 func init() {
-	Init(DefaultConfig())
+	// The default configuration logs to stderr, which cannot fail
+	_ = Init(DefaultConfig())
 }
@@ -0,0 +1,97 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// rotatingFile is a log file that is rotated when a write would take it
+// past its maximum size: the file is renamed to path.1, path.1 to path.2
+// and so on, keeping maxBackups rotated files, and a new file is started.
+// It is not safe for concurrent use.
+type rotatingFile struct {
+	path       string
+	maxSize    int64 // bytes (0 = never rotate)
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// openRotatingFile opens a log file for appending, creating it if needed.
+func openRotatingFile(path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	f := &rotatingFile{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	f.file, f.size = file, info.Size()
+	return nil
+}
+
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	if f.maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// rotate closes the file, shifts it and its backups up by one, dropping
+// the oldest, and opens a new file.
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+
+	var err error
+	if f.maxBackups == 0 {
+		err = os.Remove(f.path)
+	} else {
+		for i := f.maxBackups - 1; i >= 1; i-- {
+			if err := os.Rename(f.backup(i), f.backup(i+1)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("failed to rotate log file: %w", err)
+			}
+		}
+		err = os.Rename(f.path, f.backup(1))
+	}
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+	return f.open()
+}
+
+// backup returns the path of the nth rotated file.
+func (f *rotatingFile) backup(n int) string {
+	return fmt.Sprintf("%s.%d", f.path, n)
+}
+
+// Close closes the file.
+func (f *rotatingFile) Close() error {
+	return f.file.Close()
+}
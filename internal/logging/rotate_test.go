@@ -0,0 +1,98 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRotatingFile(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxSize    int64
+		maxBackups int
+		want       []string // contents of the file and its backups
+	}{
+		{
+			name:       "keeps backups",
+			maxSize:    10,
+			maxBackups: 2,
+			want:       []string{"line 4\n", "line 3\n", "line 2\n"},
+		},
+		{
+			name:       "no backups",
+			maxSize:    10,
+			maxBackups: 0,
+			want:       []string{"line 4\n"},
+		},
+		{
+			name:       "never rotates",
+			maxSize:    0,
+			maxBackups: 2,
+			want:       []string{"line 1\nline 2\nline 3\nline 4\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "loadgen.log")
+			f, err := openRotatingFile(path, tt.maxSize, tt.maxBackups)
+			if err != nil {
+				t.Fatalf("Failed to open log file: %v", err)
+			}
+			for _, line := range []string{"line 1\n", "line 2\n", "line 3\n", "line 4\n"} {
+				if _, err := f.Write([]byte(line)); err != nil {
+					t.Fatalf("Failed to write log file: %v", err)
+				}
+			}
+			f.Close()
+
+			for i, want := range tt.want {
+				name := path
+				if i > 0 {
+					name = f.backup(i)
+				}
+				got, err := os.ReadFile(name)
+				if err != nil {
+					t.Fatalf("Failed to read %s: %v", name, err)
+				}
+				if string(got) != want {
+					t.Errorf("Expected %s to contain %q, got %q", name, want, got)
+				}
+			}
+			if _, err := os.Stat(f.backup(len(tt.want))); !os.IsNotExist(err) {
+				t.Errorf("Expected only %d rotated files", len(tt.want)-1)
+			}
+		})
+	}
+}
+
+func TestRotatingFileAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "loadgen.log")
+	if err := os.WriteFile(path, []byte("line 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The size of an existing file counts towards rotation
+	f, err := openRotatingFile(path, 10, 1)
+	if err != nil {
+		t.Fatalf("Failed to open log file: %v", err)
+	}
+	if _, err := f.Write([]byte("line 2\n")); err != nil {
+		t.Fatalf("Failed to write log file: %v", err)
+	}
+	f.Close()
+
+	if got, _ := os.ReadFile(f.backup(1)); string(got) != "line 1\n" {
+		t.Errorf("Expected the existing file to be rotated, got %q", got)
+	}
+}
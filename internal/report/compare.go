@@ -0,0 +1,172 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package report
+
+import (
+	"fmt"
+	"sort"
+)
+
+// TotalName is the query name used for the totals across all queries in a
+// comparison.
+const TotalName = "(total)"
+
+// Thresholds configures when a comparison is considered a regression.
+// A zero value disables the corresponding check.
+type Thresholds struct {
+	// MaxQPSDecrease is the maximum allowed throughput decrease in percent.
+	MaxQPSDecrease float64
+
+	// MaxErrorRateIncrease is the maximum allowed error rate increase in
+	// percentage points.
+	MaxErrorRateIncrease float64
+
+	// MaxLatencyIncrease is the maximum allowed increase in percent of the
+	// latency percentile selected by Percentile.
+	MaxLatencyIncrease float64
+
+	// Percentile is the latency percentile checked against
+	// MaxLatencyIncrease: p50, p90, p95, p99 or p999 (default p99).
+	Percentile string
+}
+
+// Comparison holds the differences between a baseline and another report.
+type Comparison struct {
+	Baseline *Report
+	Other    *Report
+	Queries  []QueryComparison
+
+	// Regressions describes every threshold that was exceeded.
+	Regressions []string
+}
+
+// QueryComparison holds the baseline and compared results for a query type.
+// Either side is nil if the query only appears in one report.
+type QueryComparison struct {
+	Name     string
+	Baseline *QueryStats
+	Other    *QueryStats
+}
+
+// Percentiles lists the latency percentiles that can be compared, in order.
+var Percentiles = []string{"p50", "p90", "p95", "p99", "p999"}
+
+// LatencyPercentile returns the named percentile from latency statistics.
+func LatencyPercentile(l Latency, name string) (float64, error) {
+	switch name {
+	case "p50":
+		return l.P50, nil
+	case "p90":
+		return l.P90, nil
+	case "p95":
+		return l.P95, nil
+	case "", "p99":
+		return l.P99, nil
+	case "p999":
+		return l.P999, nil
+	}
+	return 0, fmt.Errorf("unknown latency percentile: %s", name)
+}
+
+// PercentChange returns the change from base to other in percent. It returns
+// 0 if base is zero.
+func PercentChange(base, other float64) float64 {
+	if base == 0 {
+		return 0
+	}
+	return (other - base) / base * 100
+}
+
+// Compare compares a report against a baseline and checks the thresholds.
+func Compare(baseline, other *Report, th Thresholds) (*Comparison, error) {
+	if _, err := LatencyPercentile(Latency{}, th.Percentile); err != nil {
+		return nil, err
+	}
+
+	c := &Comparison{Baseline: baseline, Other: other}
+
+	baseTotal, otherTotal := baseline.Summary, other.Summary
+	c.Queries = append(c.Queries, QueryComparison{
+		Name:     TotalName,
+		Baseline: &baseTotal,
+		Other:    &otherTotal,
+	})
+
+	byName := make(map[string]*QueryComparison)
+	var names []string
+	for _, stats := range [][]QueryStats{baseline.Queries, other.Queries} {
+		for _, q := range stats {
+			if _, ok := byName[q.Name]; !ok {
+				byName[q.Name] = &QueryComparison{Name: q.Name}
+				names = append(names, q.Name)
+			}
+		}
+	}
+	for i := range baseline.Queries {
+		byName[baseline.Queries[i].Name].Baseline = &baseline.Queries[i]
+	}
+	for i := range other.Queries {
+		byName[other.Queries[i].Name].Other = &other.Queries[i]
+	}
+
+	sort.Strings(names)
+	for _, name := range names {
+		c.Queries = append(c.Queries, *byName[name])
+	}
+
+	for _, q := range c.Queries {
+		c.Regressions = append(c.Regressions, q.check(th)...)
+	}
+
+	return c, nil
+}
+
+// check returns the thresholds exceeded by a query comparison.
+func (q QueryComparison) check(th Thresholds) []string {
+	if q.Baseline == nil || q.Other == nil {
+		return nil
+	}
+
+	var regressions []string
+
+	if th.MaxQPSDecrease > 0 {
+		change := PercentChange(q.Baseline.QPS, q.Other.QPS)
+		if -change > th.MaxQPSDecrease {
+			regressions = append(regressions, fmt.Sprintf(
+				"%s: throughput decreased by %.1f%% (%.2f -> %.2f qps, threshold %.1f%%)",
+				q.Name, -change, q.Baseline.QPS, q.Other.QPS, th.MaxQPSDecrease))
+		}
+	}
+
+	if th.MaxErrorRateIncrease > 0 {
+		base, other := q.Baseline.ErrorRate()*100, q.Other.ErrorRate()*100
+		if other-base > th.MaxErrorRateIncrease {
+			regressions = append(regressions, fmt.Sprintf(
+				"%s: error rate increased by %.2f points (%.2f%% -> %.2f%%, threshold %.2f)",
+				q.Name, other-base, base, other, th.MaxErrorRateIncrease))
+		}
+	}
+
+	if th.MaxLatencyIncrease > 0 {
+		percentile := th.Percentile
+		if percentile == "" {
+			percentile = "p99"
+		}
+		base, _ := LatencyPercentile(q.Baseline.Latency, percentile)
+		other, _ := LatencyPercentile(q.Other.Latency, percentile)
+		if change := PercentChange(base, other); change > th.MaxLatencyIncrease {
+			regressions = append(regressions, fmt.Sprintf(
+				"%s: %s latency increased by %.1f%% (%.2f -> %.2f ms, threshold %.1f%%)",
+				q.Name, percentile, change, base, other, th.MaxLatencyIncrease))
+		}
+	}
+
+	return regressions
+}
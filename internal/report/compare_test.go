@@ -0,0 +1,115 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package report
+
+import (
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	baseline := testReport()
+	other := testReport()
+	other.Summary.QPS = 8
+	other.Queries = []QueryStats{
+		{Name: "new_order", Count: 600, Errors: 30, QPS: 5, Latency: Latency{P50: 6}},
+		{Name: "stock_level", Count: 10, QPS: 0.1},
+	}
+
+	c, err := Compare(baseline, other, Thresholds{})
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+
+	names := []string{TotalName, "new_order", "order_status", "stock_level"}
+	if len(c.Queries) != len(names) {
+		t.Fatalf("Expected %d queries, got %d", len(names), len(c.Queries))
+	}
+	for i, name := range names {
+		if c.Queries[i].Name != name {
+			t.Errorf("Query %d: expected %s, got %s", i, name, c.Queries[i].Name)
+		}
+	}
+	if c.Queries[2].Other != nil {
+		t.Error("Expected order_status to be missing from compared report")
+	}
+	if c.Queries[3].Baseline != nil {
+		t.Error("Expected stock_level to be missing from baseline report")
+	}
+	if len(c.Regressions) != 0 {
+		t.Errorf("Expected no regressions without thresholds, got %v", c.Regressions)
+	}
+}
+
+func TestCompareThresholds(t *testing.T) {
+	baseline := testReport()
+	other := testReport()
+	other.Summary.QPS = 8           // -20%
+	other.Summary.Latency.P99 = 25  // +25%
+	other.Summary.Latency.P95 = 100 // not checked unless selected
+	other.Queries[0].Errors = 30    // 0.5% -> 5%
+
+	tests := []struct {
+		name       string
+		thresholds Thresholds
+		expected   int
+	}{
+		{"qps within threshold", Thresholds{MaxQPSDecrease: 25}, 0},
+		{"qps exceeded", Thresholds{MaxQPSDecrease: 10}, 1},
+		{"error rate within threshold", Thresholds{MaxErrorRateIncrease: 5}, 0},
+		{"error rate exceeded", Thresholds{MaxErrorRateIncrease: 1}, 1},
+		{"default percentile exceeded", Thresholds{MaxLatencyIncrease: 20}, 1},
+		{"p99 within threshold", Thresholds{MaxLatencyIncrease: 30, Percentile: "p99"}, 0},
+		{"p50 unchanged", Thresholds{MaxLatencyIncrease: 1, Percentile: "p50"}, 0},
+		{"all exceeded", Thresholds{MaxQPSDecrease: 10, MaxErrorRateIncrease: 1, MaxLatencyIncrease: 20}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := Compare(baseline, other, tt.thresholds)
+			if err != nil {
+				t.Fatalf("Compare failed: %v", err)
+			}
+			if len(c.Regressions) != tt.expected {
+				t.Errorf("Expected %d regressions, got %d: %v", tt.expected, len(c.Regressions), c.Regressions)
+			}
+		})
+	}
+}
+
+func TestCompareTimeouts(t *testing.T) {
+	other := testReport()
+	other.Queries[0].Timeouts = 30 // 0.5% -> 5%, with no other errors
+
+	c, err := Compare(testReport(), other, Thresholds{MaxErrorRateIncrease: 1})
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+	if len(c.Regressions) != 1 {
+		t.Errorf("Expected timeouts to count towards the error rate, got %v", c.Regressions)
+	}
+}
+
+func TestCompareInvalidPercentile(t *testing.T) {
+	if _, err := Compare(testReport(), testReport(), Thresholds{Percentile: "p42"}); err == nil {
+		t.Error("Expected error for invalid percentile")
+	}
+}
+
+func TestPercentChange(t *testing.T) {
+	if got := PercentChange(100, 120); got != 20 {
+		t.Errorf("Expected +20%%, got %v", got)
+	}
+	if got := PercentChange(100, 50); got != -50 {
+		t.Errorf("Expected -50%%, got %v", got)
+	}
+	if got := PercentChange(0, 50); got != 0 {
+		t.Errorf("Expected 0 for zero base, got %v", got)
+	}
+}
@@ -0,0 +1,480 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+// Package report implements machine-readable run summary reports.
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pgEdge/pgedge-loadgen/internal/config"
+)
+
+// Report is the complete result of a load simulation run.
+type Report struct {
+	// Version is the pgedge-loadgen version that produced the report.
+	Version string `json:"version"`
+
+	// App is the application that was run.
+	App string `json:"app"`
+
+	// Profile is the usage profile that was simulated.
+	Profile string `json:"profile"`
+
+	// ConnectionMode is the connection mode that was used.
+	ConnectionMode string `json:"connection_mode"`
+
+	// StartTime and EndTime bound the run.
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+
+	// DurationSeconds is the elapsed run time in seconds.
+	DurationSeconds float64 `json:"duration_seconds"`
+
+	// Config is the run configuration that was used.
+	Config config.RunConfig `json:"config"`
+
+	// Metadata is the database metadata recorded by the init command.
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Summary holds the totals across all query types.
+	Summary QueryStats `json:"summary"`
+
+	// Queries holds the totals for each query type, sorted by name.
+	Queries []QueryStats `json:"queries"`
+
+	// Intervals is the time series of per-interval statistics.
+	Intervals []Interval `json:"intervals"`
+
+	// Phases holds the totals for each load phase, if the run had a phase
+	// schedule.
+	Phases []PhaseStats `json:"phases,omitempty"`
+
+	// Reconnects is the number of lost worker connections that were
+	// re-established.
+	Reconnects int64 `json:"reconnects"`
+
+	// Retries is the number of query retries by error class.
+	Retries map[string]int64 `json:"retries,omitempty"`
+
+	// ErrorSamples holds the distinct error messages of the failed and
+	// timed-out queries, the most frequent first, up to a limit.
+	ErrorSamples []ErrorSample `json:"error_samples,omitempty"`
+
+	// Availability holds the outages seen in availability mode.
+	Availability *Availability `json:"availability,omitempty"`
+
+	// Pool holds the statistics of the connection pool shared by the
+	// workers, if there was one.
+	Pool *PoolStats `json:"pool,omitempty"`
+
+	// Connects holds the totals for the connections opened by workers, in
+	// churn connection mode.
+	Connects *ConnectStats `json:"connects,omitempty"`
+
+	// Server holds the changes in the server's statistics over the run,
+	// if they were sampled.
+	Server *ServerStats `json:"server,omitempty"`
+}
+
+// QueryStats holds the totals for a query type, or for all queries.
+// Queries that timed out are counted in Timeouts and not in Errors.
+// Failures counts both by failure class (e.g. "deadlock" or "timeout").
+type QueryStats struct {
+	Name     string           `json:"name"`
+	Count    int64            `json:"count"`
+	Errors   int64            `json:"errors"`
+	Timeouts int64            `json:"timeouts,omitempty"`
+	Failures map[string]int64 `json:"failures,omitempty"`
+	QPS      float64          `json:"qps"`
+	Latency  Latency          `json:"latency"`
+}
+
+// ErrorSample is a distinct error message, with the failure class and
+// query type of its first occurrence and the number of occurrences.
+type ErrorSample struct {
+	Class   string `json:"class"`
+	Query   string `json:"query"`
+	Message string `json:"message"`
+	Count   int64  `json:"count"`
+}
+
+// ErrorRate returns the fraction of queries that failed, including those
+// that timed out.
+func (q QueryStats) ErrorRate() float64 {
+	if q.Count == 0 {
+		return 0
+	}
+	return float64(q.Errors+q.Timeouts) / float64(q.Count)
+}
+
+// Interval holds the statistics for a single report interval.
+type Interval struct {
+	Time time.Time `json:"time"`
+
+	// ProfileTime is the time seen by the usage profile, which differs
+	// from Time when the profile is played back from a different start
+	// time or at an accelerated rate.
+	ProfileTime    time.Time `json:"profile_time"`
+	ActivityLevel  float64   `json:"activity_level"`
+	Count          int64     `json:"count"`
+	Errors         int64     `json:"errors"`
+	Timeouts       int64     `json:"timeouts,omitempty"`
+	QPS            float64   `json:"qps"`
+	ActiveSessions int64     `json:"active_sessions,omitempty"`
+	Workers        int64     `json:"workers"`
+	Phase          string    `json:"phase,omitempty"`
+	Reconnects     int64     `json:"reconnects,omitempty"`
+	Retries        int64     `json:"retries,omitempty"`
+	Latency        Latency   `json:"latency"`
+
+	// Queries holds the number of queries of each query type completed
+	// during the interval, leaving out the types with none.
+	Queries map[string]int64 `json:"queries,omitempty"`
+
+	// PoolInUse, PoolWaits and AcquireLatency describe the shared
+	// connection pool, if there was one.
+	PoolInUse      int32    `json:"pool_in_use,omitempty"`
+	PoolWaits      int64    `json:"pool_waits,omitempty"`
+	AcquireLatency *Latency `json:"acquire_latency,omitempty"`
+
+	// Connects and ConnectLatency describe the connections opened in
+	// churn connection mode.
+	Connects       int64    `json:"connects,omitempty"`
+	ConnectLatency *Latency `json:"connect_latency,omitempty"`
+
+	// Server holds the changes in the server's statistics over the
+	// interval, if they were sampled.
+	Server *ServerStats `json:"server,omitempty"`
+}
+
+// PhaseStats holds the totals for a load phase.
+type PhaseStats struct {
+	Name        string    `json:"name"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+	Connections int       `json:"connections"`
+	Count       int64     `json:"count"`
+	Errors      int64     `json:"errors"`
+	Timeouts    int64     `json:"timeouts,omitempty"`
+	QPS         float64   `json:"qps"`
+	Latency     Latency   `json:"latency"`
+}
+
+// PoolStats holds the totals for a connection pool shared by the workers.
+// Acquire latency is the time a worker waited for a pooled connection;
+// waits are the acquires that found no idle connection.
+type PoolStats struct {
+	Size           int     `json:"size"`
+	Acquires       int64   `json:"acquires"`
+	Waits          int64   `json:"waits"`
+	WaitSeconds    float64 `json:"wait_seconds"`
+	AcquireLatency Latency `json:"acquire_latency"`
+}
+
+// ConnectStats holds the totals for the connections opened by workers.
+// Connect latency covers establishing and authenticating a connection.
+type ConnectStats struct {
+	Connects int64   `json:"connects"`
+	Errors   int64   `json:"errors"`
+	Latency  Latency `json:"latency"`
+}
+
+// ServerStats holds the changes in the server's statistics over an
+// interval or a run. The database counters cover all activity in the
+// database, including that of other clients; the table counters cover
+// the app's tables. Dead tuples and replication lag are the values at the
+// end. The statement counters are only set if pg_stat_statements is
+// available, and the per-table statistics and top statements only for a
+// run.
+type ServerStats struct {
+	Commits         int64            `json:"commits"`
+	Rollbacks       int64            `json:"rollbacks"`
+	BlocksRead      int64            `json:"blocks_read"`
+	BlocksHit       int64            `json:"blocks_hit"`
+	CacheHitRatio   float64          `json:"cache_hit_ratio"`
+	TuplesInserted  int64            `json:"tuples_inserted"`
+	TuplesUpdated   int64            `json:"tuples_updated"`
+	TuplesDeleted   int64            `json:"tuples_deleted"`
+	Deadlocks       int64            `json:"deadlocks"`
+	TempBytes       int64            `json:"temp_bytes"`
+	WALBytes        int64            `json:"wal_bytes"`
+	SeqScans        int64            `json:"seq_scans"`
+	IndexScans      int64            `json:"index_scans"`
+	DeadTuples      int64            `json:"dead_tuples"`
+	StatementCalls  int64            `json:"statement_calls,omitempty"`
+	StatementTimeMs float64          `json:"statement_time_ms,omitempty"`
+	Replicas        []ReplicaLag     `json:"replicas,omitempty"`
+	Tables          []TableStats     `json:"tables,omitempty"`
+	TopStatements   []StatementStats `json:"top_statements,omitempty"`
+}
+
+// ReplicaLag is the replay lag of a replica of the server.
+type ReplicaLag struct {
+	Name       string  `json:"name"`
+	Address    string  `json:"address,omitempty"`
+	LagBytes   int64   `json:"lag_bytes"`
+	LagSeconds float64 `json:"lag_seconds"`
+}
+
+// TableStats holds the changes in a table's statistics. Live and dead
+// tuples are the values at the end.
+type TableStats struct {
+	Name       string `json:"name"`
+	SeqScans   int64  `json:"seq_scans"`
+	IndexScans int64  `json:"index_scans"`
+	Inserted   int64  `json:"inserted"`
+	Updated    int64  `json:"updated"`
+	HotUpdated int64  `json:"hot_updated"`
+	Deleted    int64  `json:"deleted"`
+	Vacuums    int64  `json:"vacuums"`
+	LiveTuples int64  `json:"live_tuples"`
+	DeadTuples int64  `json:"dead_tuples"`
+}
+
+// StatementStats holds the changes in a statement's statistics from
+// pg_stat_statements.
+type StatementStats struct {
+	Query       string  `json:"query"`
+	Calls       int64   `json:"calls"`
+	TotalTimeMs float64 `json:"total_time_ms"`
+	MeanTimeMs  float64 `json:"mean_time_ms"`
+	Rows        int64   `json:"rows"`
+}
+
+// Availability holds the outages seen in availability mode, by node. A
+// node is the address of a database server that workers were connected to.
+type Availability struct {
+	Nodes   []NodeAvailability `json:"nodes"`
+	Outages []Outage           `json:"outages"`
+}
+
+// NodeAvailability holds the outage totals for a node. The recovery time
+// of a worker is the time from the start of its first failed query to its
+// first successful query.
+type NodeAvailability struct {
+	Node                string  `json:"node"`
+	Outages             int     `json:"outages"`
+	DowntimeSeconds     float64 `json:"downtime_seconds"`
+	FailedQueries       int64   `json:"failed_queries"`
+	MeanRecoverySeconds float64 `json:"mean_recovery_seconds"`
+	MaxRecoverySeconds  float64 `json:"max_recovery_seconds"`
+}
+
+// Outage is a period during which the workers connected to a node failed,
+// from the first worker's first failed query to the last worker's
+// recovery.
+type Outage struct {
+	Node               string    `json:"node"`
+	StartTime          time.Time `json:"start_time"`
+	EndTime            time.Time `json:"end_time"`
+	DowntimeSeconds    float64   `json:"downtime_seconds"`
+	Workers            int       `json:"workers"`
+	FailedQueries      int64     `json:"failed_queries"`
+	MaxRecoverySeconds float64   `json:"max_recovery_seconds"`
+	RecoveredOn        []string  `json:"recovered_on,omitempty"`
+	Recovered          bool      `json:"recovered"`
+}
+
+// Latency holds latency statistics in milliseconds.
+type Latency struct {
+	Mean float64 `json:"mean_ms"`
+	P50  float64 `json:"p50_ms"`
+	P90  float64 `json:"p90_ms"`
+	P95  float64 `json:"p95_ms"`
+	P99  float64 `json:"p99_ms"`
+	P999 float64 `json:"p999_ms"`
+	Max  float64 `json:"max_ms"`
+}
+
+// Write writes the report to a file. The format is CSV if the file name
+// ends in .csv and JSON otherwise.
+func Write(path string, r *Report) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		err = WriteCSV(f, r)
+	} else {
+		err = WriteJSON(f, r)
+	}
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write report file: %w", err)
+	}
+
+	return f.Close()
+}
+
+// Load reads a JSON report from a file.
+func Load(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report file: %w", err)
+	}
+
+	var r Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("failed to parse report file %s: %w", path, err)
+	}
+	return &r, nil
+}
+
+// WriteJSON writes the report as indented JSON.
+func WriteJSON(w io.Writer, r *Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// csvHeader is the header row of a CSV report.
+var csvHeader = []string{
+	"type", "time", "query", "activity_level", "count", "errors", "qps",
+	"mean_ms", "p50_ms", "p90_ms", "p95_ms", "p99_ms", "p999_ms", "max_ms",
+	"profile_time", "workers", "phase", "reconnects", "retries",
+	"timeouts",
+}
+
+// csvRow is a row of a CSV report. Columns that do not apply to a row
+// type are left empty.
+type csvRow struct {
+	kind        string
+	time        time.Time
+	query       string
+	activity    string
+	count       int64
+	errors      int64
+	qps         float64
+	latency     Latency
+	profileTime string
+	workers     string
+	phase       string
+	reconnects  string
+	retries     string
+	timeouts    string
+}
+
+func (r csvRow) record() []string {
+	l := r.latency
+	return []string{
+		r.kind,
+		r.time.UTC().Format(time.RFC3339),
+		r.query,
+		r.activity,
+		strconv.FormatInt(r.count, 10),
+		strconv.FormatInt(r.errors, 10),
+		formatFloat(r.qps),
+		formatFloat(l.Mean),
+		formatFloat(l.P50),
+		formatFloat(l.P90),
+		formatFloat(l.P95),
+		formatFloat(l.P99),
+		formatFloat(l.P999),
+		formatFloat(l.Max),
+		r.profileTime,
+		r.workers,
+		r.phase,
+		r.reconnects,
+		r.retries,
+		r.timeouts,
+	}
+}
+
+// WriteCSV writes the report as CSV. Each row has a type column: "summary"
+// for the run totals, "query" for each query type, "phase" for each load
+// phase and "interval" for each report interval. The configuration and
+// metadata are only included in JSON reports.
+func WriteCSV(w io.Writer, r *Report) error {
+	var retries int64
+	for _, n := range r.Retries {
+		retries += n
+	}
+
+	s := r.Summary
+	rows := []csvRow{{
+		kind:       "summary",
+		time:       r.EndTime,
+		count:      s.Count,
+		errors:     s.Errors,
+		qps:        s.QPS,
+		latency:    s.Latency,
+		reconnects: strconv.FormatInt(r.Reconnects, 10),
+		retries:    strconv.FormatInt(retries, 10),
+		timeouts:   strconv.FormatInt(s.Timeouts, 10),
+	}}
+	for _, q := range r.Queries {
+		rows = append(rows, csvRow{
+			kind:     "query",
+			time:     r.EndTime,
+			query:    q.Name,
+			count:    q.Count,
+			errors:   q.Errors,
+			qps:      q.QPS,
+			latency:  q.Latency,
+			timeouts: strconv.FormatInt(q.Timeouts, 10),
+		})
+	}
+	for _, p := range r.Phases {
+		rows = append(rows, csvRow{
+			kind:     "phase",
+			time:     p.EndTime,
+			count:    p.Count,
+			errors:   p.Errors,
+			qps:      p.QPS,
+			latency:  p.Latency,
+			timeouts: strconv.FormatInt(p.Timeouts, 10),
+			workers:  strconv.Itoa(p.Connections),
+			phase:    p.Name,
+		})
+	}
+	for _, i := range r.Intervals {
+		rows = append(rows, csvRow{
+			kind:        "interval",
+			time:        i.Time,
+			activity:    formatFloat(i.ActivityLevel),
+			count:       i.Count,
+			errors:      i.Errors,
+			qps:         i.QPS,
+			latency:     i.Latency,
+			profileTime: i.ProfileTime.Format(time.RFC3339),
+			workers:     strconv.FormatInt(i.Workers, 10),
+			phase:       i.Phase,
+			reconnects:  strconv.FormatInt(i.Reconnects, 10),
+			retries:     strconv.FormatInt(i.Retries, 10),
+			timeouts:    strconv.FormatInt(i.Timeouts, 10),
+		})
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := cw.Write(row.record()); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', 3, 64)
+}
@@ -0,0 +1,215 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package report
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pgEdge/pgedge-loadgen/internal/config"
+)
+
+func testReport() *Report {
+	start := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	return &Report{
+		Version:         "1.0.0",
+		App:             "wholesale",
+		Profile:         "local-office",
+		ConnectionMode:  "pool",
+		StartTime:       start,
+		EndTime:         start.Add(2 * time.Minute),
+		DurationSeconds: 120,
+		Config:          config.RunConfig{Connections: 10, Profile: "local-office"},
+		Metadata:        map[string]string{"app": "wholesale", "target_size": "1GB"},
+		Summary: QueryStats{
+			Count: 1200, Errors: 3, Timeouts: 2, QPS: 10,
+			Failures: map[string]int64{"deadlock": 3, "timeout": 2},
+			Latency:  Latency{Mean: 5, P50: 4, P99: 20, Max: 45},
+		},
+		Queries: []QueryStats{
+			{Name: "new_order", Count: 600, Errors: 3, QPS: 5, Latency: Latency{P50: 6}},
+			{Name: "order_status", Count: 600, Timeouts: 2, QPS: 5, Latency: Latency{P50: 2}},
+		},
+		Intervals: []Interval{
+			{Time: start.Add(time.Minute), ActivityLevel: 0.5, Count: 600, QPS: 10, Workers: 5, Phase: "ramp"},
+			{Time: start.Add(2 * time.Minute), ActivityLevel: 0.75, Count: 600, Errors: 3, QPS: 10, Workers: 10, Phase: "hold",
+				Reconnects: 2, Retries: 1, Timeouts: 2},
+		},
+		Phases: []PhaseStats{
+			{Name: "ramp", StartTime: start, EndTime: start.Add(time.Minute), Connections: 10, Count: 600, QPS: 10},
+			{Name: "hold", StartTime: start.Add(time.Minute), EndTime: start.Add(2 * time.Minute), Connections: 10, Count: 600, Errors: 3, QPS: 10},
+		},
+		Reconnects: 2,
+		Retries:    map[string]int64{"serialization_failure": 4, "connection": 1},
+		ErrorSamples: []ErrorSample{
+			{Class: "deadlock", Query: "new_order", Message: "ERROR: deadlock detected (SQLSTATE 40P01)", Count: 3},
+		},
+		Connects: &ConnectStats{Connects: 240, Errors: 1, Latency: Latency{Mean: 4.5, P99: 12}},
+		Server: &ServerStats{
+			Commits: 1150, Rollbacks: 3, BlocksHit: 9900, BlocksRead: 100, CacheHitRatio: 0.99,
+			WALBytes: 1 << 20, StatementCalls: 4000, StatementTimeMs: 6000,
+			Replicas: []ReplicaLag{{Name: "standby1", LagBytes: 4096, LagSeconds: 0.5}},
+			Tables:   []TableStats{{Name: "orders", Inserted: 600, LiveTuples: 30600}},
+			TopStatements: []StatementStats{
+				{Query: "UPDATE stock SET s_quantity = $1", Calls: 600, TotalTimeMs: 900, MeanTimeMs: 1.5},
+			},
+		},
+		Pool: &PoolStats{
+			Size: 5, Acquires: 1200, Waits: 300, WaitSeconds: 4.5,
+			AcquireLatency: Latency{Mean: 3.75, P99: 40, Max: 60},
+		},
+		Availability: &Availability{
+			Nodes: []NodeAvailability{
+				{Node: "10.0.0.1:5432", Outages: 1, DowntimeSeconds: 12.5, FailedQueries: 40,
+					MeanRecoverySeconds: 9, MaxRecoverySeconds: 12.5},
+			},
+			Outages: []Outage{
+				{Node: "10.0.0.1:5432", StartTime: start.Add(30 * time.Second),
+					EndTime: start.Add(42500 * time.Millisecond), DowntimeSeconds: 12.5, Workers: 10,
+					FailedQueries: 40, MaxRecoverySeconds: 12.5, RecoveredOn: []string{"10.0.0.2:5432"},
+					Recovered: true},
+			},
+		},
+	}
+}
+
+func TestWriteLoadJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summary.json")
+	r := testReport()
+
+	if err := Write(path, r); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if loaded.App != r.App || !loaded.StartTime.Equal(r.StartTime) {
+		t.Errorf("Loaded report header mismatch: %+v", loaded)
+	}
+	if loaded.Config.Connections != 10 {
+		t.Errorf("Expected config connections 10, got %d", loaded.Config.Connections)
+	}
+	if loaded.Metadata["target_size"] != "1GB" {
+		t.Errorf("Expected metadata target_size 1GB, got %q", loaded.Metadata["target_size"])
+	}
+	if loaded.Summary.Timeouts != 2 || loaded.Queries[1].Timeouts != 2 {
+		t.Errorf("Loaded timeouts mismatch: %+v", loaded.Summary)
+	}
+	if len(loaded.Queries) != 2 || loaded.Queries[0].Latency.P50 != 6 {
+		t.Errorf("Loaded queries mismatch: %+v", loaded.Queries)
+	}
+	if len(loaded.Intervals) != 2 || loaded.Intervals[1].ActivityLevel != 0.75 ||
+		loaded.Intervals[1].Phase != "hold" {
+		t.Errorf("Loaded intervals mismatch: %+v", loaded.Intervals)
+	}
+	if len(loaded.Phases) != 2 || loaded.Phases[1].Errors != 3 {
+		t.Errorf("Loaded phases mismatch: %+v", loaded.Phases)
+	}
+	if loaded.Summary.Failures["deadlock"] != 3 || len(loaded.ErrorSamples) != 1 ||
+		loaded.ErrorSamples[0].Count != 3 {
+		t.Errorf("Loaded failures mismatch: %v, %+v", loaded.Summary.Failures, loaded.ErrorSamples)
+	}
+	if loaded.Reconnects != 2 || loaded.Retries["serialization_failure"] != 4 {
+		t.Errorf("Loaded reconnects and retries mismatch: %d, %v", loaded.Reconnects, loaded.Retries)
+	}
+	if c := loaded.Connects; c == nil || c.Connects != 240 || c.Latency.P99 != 12 {
+		t.Errorf("Loaded connects mismatch: %+v", loaded.Connects)
+	}
+	if s := loaded.Server; s == nil || s.Commits != 1150 || s.WALBytes != 1<<20 ||
+		len(s.Replicas) != 1 || len(s.Tables) != 1 || s.TopStatements[0].MeanTimeMs != 1.5 {
+		t.Errorf("Loaded server statistics mismatch: %+v", loaded.Server)
+	}
+	if p := loaded.Pool; p == nil || p.Size != 5 || p.Waits != 300 || p.AcquireLatency.P99 != 40 {
+		t.Errorf("Loaded pool mismatch: %+v", loaded.Pool)
+	}
+	if a := loaded.Availability; a == nil || len(a.Nodes) != 1 || len(a.Outages) != 1 ||
+		a.Outages[0].DowntimeSeconds != 12.5 || a.Outages[0].RecoveredOn[0] != "10.0.0.2:5432" {
+		t.Errorf("Loaded availability mismatch: %+v", loaded.Availability)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summary.csv")
+
+	if err := Write(path, testReport()); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open CSV: %v", err)
+	}
+	defer f.Close()
+
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV: %v", err)
+	}
+
+	// Header, summary, 2 queries, 2 phases, 2 intervals
+	if len(records) != 8 {
+		t.Fatalf("Expected 8 CSV records, got %d", len(records))
+	}
+	if len(records[0]) != len(csvHeader) {
+		t.Errorf("Expected %d columns, got %d", len(csvHeader), len(records[0]))
+	}
+
+	types := []string{"type", "summary", "query", "query", "phase", "phase", "interval", "interval"}
+	for i, record := range records {
+		if record[0] != types[i] {
+			t.Errorf("Record %d: expected type %q, got %q", i, types[i], record[0])
+		}
+	}
+	if records[2][2] != "new_order" || records[2][5] != "3" {
+		t.Errorf("Unexpected query record: %v", records[2])
+	}
+	if records[3][2] != "order_status" || records[3][19] != "2" {
+		t.Errorf("Unexpected query record: %v", records[3])
+	}
+	if summary := records[1]; summary[17] != "2" || summary[18] != "5" || summary[19] != "2" {
+		t.Errorf("Unexpected summary record: %v", summary)
+	}
+	if phase := records[4]; phase[16] != "ramp" || phase[15] != "10" {
+		t.Errorf("Unexpected phase record: %v", phase)
+	}
+	if interval := records[7]; interval[16] != "hold" || interval[15] != "10" ||
+		interval[17] != "2" || interval[18] != "1" || interval[19] != "2" {
+		t.Errorf("Unexpected interval record: %v", interval)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.json")
+	if err := os.WriteFile(path, []byte("not json"), 0600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	if _, err := Load(path); err == nil {
+		t.Error("Expected error for invalid report file")
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Expected error for missing report file")
+	}
+}
+
+func TestErrorRate(t *testing.T) {
+	if rate := (QueryStats{Count: 200, Errors: 5}).ErrorRate(); rate != 0.025 {
+		t.Errorf("Expected error rate 0.025, got %v", rate)
+	}
+	if rate := (QueryStats{}).ErrorRate(); rate != 0 {
+		t.Errorf("Expected error rate 0 with no queries, got %v", rate)
+	}
+}
@@ -0,0 +1,83 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package report
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// timeSeriesHeader is the header row of a time series file, before the
+// query count columns.
+var timeSeriesHeader = []string{
+	"time", "profile_time", "activity_level", "workers", "active_sessions",
+	"phase", "count", "errors", "timeouts", "qps",
+	"mean_ms", "p50_ms", "p90_ms", "p95_ms", "p99_ms", "p999_ms", "max_ms",
+}
+
+// TimeSeriesWriter writes report intervals as CSV rows as they complete,
+// so that a long run can be followed and plotted while it is in progress.
+// After the interval totals, each row has the number of queries of each
+// query type, in columns named after the query with a _count suffix.
+type TimeSeriesWriter struct {
+	cw      *csv.Writer
+	queries []string
+}
+
+// NewTimeSeriesWriter writes the header row of a time series with a count
+// column for each of the named queries.
+func NewTimeSeriesWriter(w io.Writer, queries []string) (*TimeSeriesWriter, error) {
+	t := &TimeSeriesWriter{cw: csv.NewWriter(w), queries: queries}
+
+	header := append([]string{}, timeSeriesHeader...)
+	for _, name := range queries {
+		header = append(header, name+"_count")
+	}
+	if err := t.cw.Write(header); err != nil {
+		return nil, err
+	}
+	t.cw.Flush()
+	return t, t.cw.Error()
+}
+
+// Write writes an interval's row and flushes it.
+func (t *TimeSeriesWriter) Write(i Interval) error {
+	l := i.Latency
+	record := []string{
+		i.Time.UTC().Format(time.RFC3339),
+		i.ProfileTime.Format(time.RFC3339),
+		formatFloat(i.ActivityLevel),
+		strconv.FormatInt(i.Workers, 10),
+		strconv.FormatInt(i.ActiveSessions, 10),
+		i.Phase,
+		strconv.FormatInt(i.Count, 10),
+		strconv.FormatInt(i.Errors, 10),
+		strconv.FormatInt(i.Timeouts, 10),
+		formatFloat(i.QPS),
+		formatFloat(l.Mean),
+		formatFloat(l.P50),
+		formatFloat(l.P90),
+		formatFloat(l.P95),
+		formatFloat(l.P99),
+		formatFloat(l.P999),
+		formatFloat(l.Max),
+	}
+	for _, name := range t.queries {
+		record = append(record, strconv.FormatInt(i.Queries[name], 10))
+	}
+
+	if err := t.cw.Write(record); err != nil {
+		return err
+	}
+	t.cw.Flush()
+	return t.cw.Error()
+}
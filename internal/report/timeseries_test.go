@@ -0,0 +1,79 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+)
+
+func TestTimeSeriesWriter(t *testing.T) {
+	var buf bytes.Buffer
+	ts, err := NewTimeSeriesWriter(&buf, []string{"new_order", "payment"})
+	if err != nil {
+		t.Fatalf("NewTimeSeriesWriter failed: %v", err)
+	}
+
+	// The header is written before the first interval
+	if !bytes.HasPrefix(buf.Bytes(), []byte("time,profile_time,")) {
+		t.Errorf("Expected header row, got %q", buf.String())
+	}
+
+	start := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	for i, phase := range []string{"ramp", "hold"} {
+		err := ts.Write(Interval{
+			Time:           start.Add(time.Duration(i+1) * time.Minute),
+			ProfileTime:    start.Add(time.Duration(i+1) * time.Hour),
+			ActivityLevel:  0.5,
+			Count:          600,
+			Errors:         2,
+			QPS:            10,
+			ActiveSessions: 4,
+			Workers:        8,
+			Phase:          phase,
+			Latency:        Latency{Mean: 5, P95: 12.5},
+			Queries:        map[string]int64{"new_order": 600},
+		})
+		if err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse time series: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected header and 2 rows, got %d rows", len(records))
+	}
+	header, row := records[0], records[2]
+	if len(row) != len(header) || header[len(header)-2] != "new_order_count" ||
+		header[len(header)-1] != "payment_count" {
+		t.Errorf("Unexpected header: %v", header)
+	}
+	want := map[string]string{
+		"time":            "2026-01-05T09:02:00Z",
+		"profile_time":    "2026-01-05T11:00:00Z",
+		"activity_level":  "0.500",
+		"active_sessions": "4",
+		"phase":           "hold",
+		"errors":          "2",
+		"p95_ms":          "12.500",
+		"new_order_count": "600",
+		"payment_count":   "0",
+	}
+	for i, column := range header {
+		if v, ok := want[column]; ok && row[i] != v {
+			t.Errorf("Expected %s %q, got %q", column, v, row[i])
+		}
+	}
+}
@@ -0,0 +1,234 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package workload
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/pgEdge/pgedge-loadgen/internal/apps"
+	"github.com/pgEdge/pgedge-loadgen/internal/report"
+)
+
+// workerOutage is a period during which a worker's queries failed, from
+// the start of its first failed query to its first successful query.
+type workerOutage struct {
+	worker        int
+	node          string // node the worker was connected to when it failed
+	recoveredNode string // node of the first successful query
+	start         time.Time
+	end           time.Time
+	failed        int64
+	recovered     bool
+}
+
+// isOutageError reports whether a query error indicates that the node is
+// unavailable: the connection was lost, or the node has become read-only
+// (e.g. a former primary after a failover).
+func isOutageError(err error, lost bool) bool {
+	if errorClass(err, lost) == classConnection {
+		return true
+	}
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "25006" // read_only_sql_transaction
+}
+
+// observe updates the worker's outage state with the outcome of a query
+// that started on node at start. outageErr reports whether any attempt of
+// the query failed with an outage error.
+func (w *workerConn) observe(result apps.QueryResult, node string, start time.Time, outageErr bool) {
+	if errors.Is(result.Error, context.Canceled) ||
+		errors.Is(result.Error, context.DeadlineExceeded) {
+		return
+	}
+
+	if w.outage == nil && outageErr {
+		w.outage = &workerOutage{worker: w.id, node: node, start: start}
+		w.e.startOutage(node, start)
+	}
+	if w.outage == nil {
+		return
+	}
+
+	if result.Error != nil {
+		w.outage.failed++
+		return
+	}
+	w.outage.end = time.Now()
+	w.outage.recoveredNode = w.node
+	w.outage.recovered = true
+	w.e.endOutage(*w.outage)
+	w.outage = nil
+}
+
+// abandonOutage ends the worker's outage, if it is in one, when the worker
+// stops without having recovered.
+func (w *workerConn) abandonOutage() {
+	if w.outage == nil {
+		return
+	}
+	w.outage.end = time.Now()
+	w.e.endOutage(*w.outage)
+	w.outage = nil
+}
+
+// startOutage records that a worker has lost access to a node.
+func (e *Executor) startOutage(node string, start time.Time) {
+	e.outagesMu.Lock()
+	defer e.outagesMu.Unlock()
+
+	e.workersDown++
+	if e.workersDown == 1 {
+		e.downSince = start
+		e.log.Warn().
+			Str("node", node).
+			Msg("Outage started")
+	}
+}
+
+// endOutage records the end of a worker's outage.
+func (e *Executor) endOutage(o workerOutage) {
+	e.outagesMu.Lock()
+	defer e.outagesMu.Unlock()
+
+	e.outages = append(e.outages, o)
+	e.workersDown--
+	if e.workersDown == 0 {
+		e.log.Info().
+			Str("node", o.node).
+			Str("recovered_on", o.recoveredNode).
+			Dur("downtime", o.end.Sub(e.downSince)).
+			Msg("Outage ended")
+	}
+}
+
+// downWorkers returns the number of workers currently in an outage.
+func (e *Executor) downWorkers() int {
+	e.outagesMu.Lock()
+	defer e.outagesMu.Unlock()
+	return e.workersDown
+}
+
+// availabilityReport merges the workers' overlapping outages on each node
+// into outage windows, and totals them by node.
+func availabilityReport(outages []workerOutage) *report.Availability {
+	byNode := make(map[string][]workerOutage)
+	for _, o := range outages {
+		byNode[o.node] = append(byNode[o.node], o)
+	}
+	nodes := make([]string, 0, len(byNode))
+	for node := range byNode {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+
+	a := &report.Availability{
+		Nodes:   []report.NodeAvailability{},
+		Outages: []report.Outage{},
+	}
+	for _, node := range nodes {
+		worker := byNode[node]
+		sort.Slice(worker, func(i, j int) bool {
+			return worker[i].start.Before(worker[j].start)
+		})
+
+		n := report.NodeAvailability{Node: node}
+		var totalRecovery float64
+		var window *report.Outage
+		workers := make(map[int]bool)
+		for _, o := range worker {
+			recovery := o.end.Sub(o.start).Seconds()
+			totalRecovery += recovery
+			n.MaxRecoverySeconds = max(n.MaxRecoverySeconds, recovery)
+			n.FailedQueries += o.failed
+
+			// Start a new window unless the outage overlaps the current one
+			if window == nil || o.start.After(window.EndTime) {
+				a.Outages = append(a.Outages, report.Outage{
+					Node:      node,
+					StartTime: o.start,
+					EndTime:   o.end,
+					Recovered: true,
+				})
+				window = &a.Outages[len(a.Outages)-1]
+				clear(workers)
+			}
+			if o.end.After(window.EndTime) {
+				window.EndTime = o.end
+			}
+			if !workers[o.worker] {
+				workers[o.worker] = true
+				window.Workers++
+			}
+			window.FailedQueries += o.failed
+			window.MaxRecoverySeconds = max(window.MaxRecoverySeconds, recovery)
+			if !o.recovered {
+				window.Recovered = false
+			} else if !slices.Contains(window.RecoveredOn, o.recoveredNode) {
+				window.RecoveredOn = append(window.RecoveredOn, o.recoveredNode)
+			}
+		}
+		n.MeanRecoverySeconds = totalRecovery / float64(len(worker))
+
+		for i := range a.Outages {
+			if o := &a.Outages[i]; o.Node == node {
+				o.DowntimeSeconds = o.EndTime.Sub(o.StartTime).Seconds()
+				sort.Strings(o.RecoveredOn)
+				n.Outages++
+				n.DowntimeSeconds += o.DowntimeSeconds
+			}
+		}
+		a.Nodes = append(a.Nodes, n)
+	}
+	return a
+}
+
+// availability returns the outages seen so far. The outages of workers
+// that stopped before recovering are included as not recovered.
+func (e *Executor) availability() *report.Availability {
+	e.outagesMu.Lock()
+	defer e.outagesMu.Unlock()
+	return availabilityReport(e.outages)
+}
+
+// logAvailability logs the outage totals for each node and the outages.
+func (e *Executor) logAvailability() {
+	a := e.availability()
+	e.log.Info().
+		Int("outages", len(a.Outages)).
+		Msg("Availability:")
+	for _, n := range a.Nodes {
+		e.log.Info().
+			Str("node", n.Node).
+			Int("outages", n.Outages).
+			Float64("downtime_s", n.DowntimeSeconds).
+			Int64("failed", n.FailedQueries).
+			Float64("mean_recovery_s", n.MeanRecoverySeconds).
+			Float64("max_recovery_s", n.MaxRecoverySeconds).
+			Msg("")
+	}
+	for _, o := range a.Outages {
+		e.log.Info().
+			Str("node", o.Node).
+			Time("start", o.StartTime).
+			Float64("downtime_s", o.DowntimeSeconds).
+			Int("workers", o.Workers).
+			Int64("failed", o.FailedQueries).
+			Float64("max_recovery_s", o.MaxRecoverySeconds).
+			Strs("recovered_on", o.RecoveredOn).
+			Bool("recovered", o.Recovered).
+			Msg("Outage")
+	}
+}
@@ -0,0 +1,138 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package workload
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/pgEdge/pgedge-loadgen/internal/apps"
+)
+
+func TestIsOutageError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		lost bool
+		want bool
+	}{
+		{"no error", nil, false, false},
+		{"connection lost", errors.New("unexpected EOF"), true, true},
+		{"admin shutdown", &pgconn.PgError{Code: "57P01"}, false, true},
+		{"read only", &pgconn.PgError{Code: "25006"}, false, true},
+		{"deadlock", &pgconn.PgError{Code: "40P01"}, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isOutageError(tt.err, tt.lost); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWorkerConnObserve(t *testing.T) {
+	e := newTestExecutor(t, ExecutorConfig{Availability: true})
+	w := &workerConn{e: e, id: 1, node: "10.0.0.1:5432"}
+	failed := apps.QueryResult{Error: &pgconn.PgError{Code: "57P01"}}
+
+	// Failures without an outage error do not start an outage
+	w.observe(apps.QueryResult{Error: errors.New("boom")}, w.node, time.Now(), false)
+	if w.outage != nil {
+		t.Fatal("Expected no outage")
+	}
+
+	start := time.Now()
+	w.observe(failed, w.node, start, true)
+	w.observe(failed, w.node, time.Now(), true)
+	if e.downWorkers() != 1 {
+		t.Errorf("Expected 1 worker down, got %d", e.downWorkers())
+	}
+
+	// The first success on the new node ends the outage
+	w.node = "10.0.0.2:5432"
+	w.observe(apps.QueryResult{}, w.node, time.Now(), false)
+	if e.downWorkers() != 0 {
+		t.Errorf("Expected no workers down, got %d", e.downWorkers())
+	}
+
+	a := e.availability()
+	if len(a.Outages) != 1 {
+		t.Fatalf("Expected 1 outage, got %d", len(a.Outages))
+	}
+	o := a.Outages[0]
+	if o.Node != "10.0.0.1:5432" || o.FailedQueries != 2 || !o.Recovered ||
+		len(o.RecoveredOn) != 1 || o.RecoveredOn[0] != "10.0.0.2:5432" {
+		t.Errorf("Outage mismatch: %+v", o)
+	}
+	if !o.StartTime.Equal(start) {
+		t.Errorf("Expected outage to start at %v, got %v", start, o.StartTime)
+	}
+
+	// A worker that stops during an outage leaves it unrecovered
+	w.observe(failed, w.node, time.Now(), true)
+	w.abandonOutage()
+	a = e.availability()
+	if len(a.Outages) != 2 || a.Outages[1].Recovered {
+		t.Errorf("Expected an unrecovered outage, got %+v", a.Outages)
+	}
+}
+
+func TestAvailabilityReport(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(s int) time.Time { return t0.Add(time.Duration(s) * time.Second) }
+
+	outages := []workerOutage{
+		// Two workers overlapping on node a, recovering on node b
+		{worker: 1, node: "a", recoveredNode: "b", start: at(0), end: at(4), failed: 3, recovered: true},
+		{worker: 2, node: "a", recoveredNode: "b", start: at(1), end: at(6), failed: 2, recovered: true},
+		// A later, separate outage on node a that did not recover
+		{worker: 1, node: "a", start: at(20), end: at(22), failed: 1},
+		// An outage on node b
+		{worker: 3, node: "b", recoveredNode: "b", start: at(10), end: at(11), failed: 1, recovered: true},
+	}
+
+	a := availabilityReport(outages)
+	if len(a.Nodes) != 2 || len(a.Outages) != 3 {
+		t.Fatalf("Expected 2 nodes and 3 outages, got %+v", a)
+	}
+
+	first := a.Outages[0]
+	if first.Node != "a" || first.Workers != 2 || first.FailedQueries != 5 ||
+		first.DowntimeSeconds != 6 || first.MaxRecoverySeconds != 5 || !first.Recovered {
+		t.Errorf("First outage mismatch: %+v", first)
+	}
+	if len(first.RecoveredOn) != 1 || first.RecoveredOn[0] != "b" {
+		t.Errorf("Expected recovery on node b, got %v", first.RecoveredOn)
+	}
+	if second := a.Outages[1]; second.Node != "a" || second.Recovered || second.DowntimeSeconds != 2 {
+		t.Errorf("Second outage mismatch: %+v", second)
+	}
+
+	n := a.Nodes[0]
+	if n.Node != "a" || n.Outages != 2 || n.DowntimeSeconds != 8 || n.FailedQueries != 6 ||
+		n.MaxRecoverySeconds != 5 {
+		t.Errorf("Node a mismatch: %+v", n)
+	}
+	if want := (4.0 + 5 + 2) / 3; n.MeanRecoverySeconds != want {
+		t.Errorf("Expected mean recovery %v, got %v", want, n.MeanRecoverySeconds)
+	}
+	if n := a.Nodes[1]; n.Node != "b" || n.Outages != 1 || n.DowntimeSeconds != 1 {
+		t.Errorf("Node b mismatch: %+v", n)
+	}
+
+	if empty := availabilityReport(nil); len(empty.Nodes) != 0 || len(empty.Outages) != 0 {
+		t.Errorf("Expected an empty report, got %+v", empty)
+	}
+}
@@ -0,0 +1,111 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package workload
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/pgEdge/pgedge-loadgen/internal/apps"
+	"github.com/pgEdge/pgedge-loadgen/internal/report"
+)
+
+// churnWorker implements the churn connection mode where clients are
+// short-lived, typical for serverless functions and clients behind a
+// connection pooler: the worker opens a new connection for each user
+// session, or for every churnQueries queries, and closes it afterwards.
+func (e *Executor) churnWorker(ctx context.Context, id int, stop <-chan struct{}) {
+	e.log.Debug().Int("worker_id", id).Msg("Churn worker started")
+
+	w := &workerConn{e: e, id: id}
+	defer w.close(ctx)
+
+	var queries apps.QueryExecutor
+	rng := rand.New(rand.NewPCG(e.workerSeed(id), 0))
+
+	for {
+		select {
+		case <-ctx.Done():
+			e.log.Debug().Int("worker_id", id).Msg("Churn worker stopped")
+			return
+		case <-stop:
+			e.log.Debug().Int("worker_id", id).Msg("Churn worker retired")
+			return
+		default:
+			// Get current activity level from profile
+			activityLevel := e.activityLevel(time.Now())
+
+			// Skip connecting if activity level is very low
+			if activityLevel < 0.01 {
+				time.Sleep(100 * time.Millisecond)
+				continue
+			}
+
+			if !w.connect(ctx, stop) {
+				return
+			}
+			if queries == nil {
+				queries = e.app.NewQueryExecutor(ctx, w.conn, e.workerSeed(id))
+			}
+
+			// Run a user session or a batch of queries, then disconnect
+			var ok bool
+			if e.churnQueries > 0 {
+				ok = e.runChurnQueries(ctx, w, queries, stop, activityLevel)
+			} else {
+				ok = e.runSession(ctx, w, queries, rng, stop, activityLevel)
+			}
+			w.disconnect(ctx)
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+// runChurnQueries runs churnQueries queries on the worker's connection,
+// with the pool mode delay between them. It returns false if the worker's
+// connection was lost and could not be re-established.
+func (e *Executor) runChurnQueries(ctx context.Context, w *workerConn,
+	queries apps.QueryExecutor, stop <-chan struct{}, activityLevel float64) bool {
+	for i := 0; i < e.churnQueries; i++ {
+		select {
+		case <-ctx.Done():
+			return true
+		case <-stop:
+			return true
+		default:
+		}
+
+		result, ok := w.execute(ctx, stop, queries)
+		e.recordResult(result)
+		if !ok {
+			return false
+		}
+
+		// Apply delay based on activity level
+		delay := e.calculateDelay(activityLevel)
+		if delay > 0 {
+			time.Sleep(delay)
+		}
+	}
+	return true
+}
+
+// connectStats returns the totals for the connections opened by workers.
+func (e *Executor) connectStats() *report.ConnectStats {
+	connectLatency := e.connectLatency.snapshot()
+	return &report.ConnectStats{
+		Connects: connectLatency.Count(),
+		Errors:   e.connectErrors.Load(),
+		Latency:  latencyStats(connectLatency),
+	}
+}
@@ -0,0 +1,45 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package workload
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestChurnWorkerConnectFailure(t *testing.T) {
+	e := newTestExecutor(t, ExecutorConfig{
+		ConnString:     "host=/nonexistent",
+		ConnectionMode: "churn",
+		ChurnQueries:   1,
+		Reconnect:      ReconnectPolicy{MaxAttempts: 2, InitialBackoff: 10 * time.Millisecond},
+	})
+
+	// The worker stops once its connection attempts run out
+	done := make(chan struct{})
+	go func() {
+		e.churnWorker(context.Background(), 0, nil)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Churn worker did not stop")
+	}
+
+	connects := e.connectStats()
+	if connects.Connects != 0 || connects.Errors != 2 {
+		t.Errorf("Expected 0 connects and 2 errors, got %+v", connects)
+	}
+	if r := e.Report(); r.Connects == nil || r.Connects.Errors != 2 {
+		t.Errorf("Expected connect totals in report, got %+v", r.Connects)
+	}
+}
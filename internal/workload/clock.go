@@ -0,0 +1,66 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package workload
+
+import (
+	"time"
+)
+
+// profileClock maps wall-clock time to the virtual time fed to the usage
+// profile. This allows a profile to be played back from a chosen start time
+// and at an accelerated rate, e.g. a week of daily patterns in a few hours.
+type profileClock struct {
+	realStart    time.Time
+	virtualStart time.Time
+	scale        float64
+}
+
+// newProfileClock creates a clock whose virtual time starts at start (or
+// the current time if zero) and advances scale times faster than real time.
+func newProfileClock(start time.Time, scale float64) *profileClock {
+	now := time.Now()
+	if start.IsZero() {
+		start = now
+	}
+	if scale <= 0 {
+		scale = 1
+	}
+	return &profileClock{
+		realStart:    now,
+		virtualStart: start,
+		scale:        scale,
+	}
+}
+
+// restart moves the clock's start to the wall-clock time now, so that the
+// time spent setting up the run is not played back. A clock whose virtual
+// time started at the current time starts at now again.
+func (c *profileClock) restart(now time.Time) {
+	if c.virtualStart.Equal(c.realStart) {
+		c.virtualStart = now
+	}
+	c.realStart = now
+}
+
+// at returns the virtual time corresponding to the wall-clock time t.
+func (c *profileClock) at(t time.Time) time.Time {
+	elapsed := float64(t.Sub(c.realStart)) * c.scale
+	return c.virtualStart.Add(time.Duration(elapsed))
+}
+
+// virtual reports whether the clock differs from wall-clock time.
+func (c *profileClock) virtual() bool {
+	return c.scale != 1 || !c.virtualStart.Equal(c.realStart)
+}
+
+// activityLevel returns the profile activity level at wall-clock time t.
+func (e *Executor) activityLevel(t time.Time) float64 {
+	return e.profile.GetActivityLevel(e.clock.at(t))
+}
@@ -0,0 +1,63 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package workload
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProfileClock(t *testing.T) {
+	start := time.Date(2026, 1, 5, 6, 0, 0, 0, time.UTC)
+	c := newProfileClock(start, 60)
+
+	if !c.virtual() {
+		t.Error("Expected scaled clock to be virtual")
+	}
+	if got := c.at(c.realStart); !got.Equal(start) {
+		t.Errorf("Expected %v at start, got %v", start, got)
+	}
+	// One real minute is one profile hour
+	if got := c.at(c.realStart.Add(time.Minute)); !got.Equal(start.Add(time.Hour)) {
+		t.Errorf("Expected %v after one minute, got %v", start.Add(time.Hour), got)
+	}
+}
+
+func TestProfileClockRealTime(t *testing.T) {
+	c := newProfileClock(time.Time{}, 0)
+
+	if c.virtual() {
+		t.Error("Expected default clock to follow wall-clock time")
+	}
+	now := time.Now()
+	if got := c.at(now); !got.Equal(now) {
+		t.Errorf("Expected %v, got %v", now, got)
+	}
+}
+
+func TestProfileClockRestart(t *testing.T) {
+	start := time.Date(2026, 1, 5, 6, 0, 0, 0, time.UTC)
+	c := newProfileClock(start, 60)
+
+	// Setup time before the run starts is not played back
+	runStart := c.realStart.Add(10 * time.Second)
+	c.restart(runStart)
+	if got := c.at(runStart); !got.Equal(start) {
+		t.Errorf("Expected %v at the run start, got %v", start, got)
+	}
+
+	// A clock without a start time starts at the run start
+	c = newProfileClock(time.Time{}, 60)
+	runStart = c.realStart.Add(10 * time.Second)
+	c.restart(runStart)
+	if got := c.at(runStart); !got.Equal(runStart) {
+		t.Errorf("Expected %v at the run start, got %v", runStart, got)
+	}
+}
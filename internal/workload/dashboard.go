@@ -0,0 +1,350 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package workload
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// dashboardRefresh is how often the dashboard is redrawn.
+	dashboardRefresh = time.Second
+
+	// sparklineWidth is the number of refreshes shown by a sparkline.
+	sparklineWidth = 30
+
+	// dashboardLogLines is the number of recent log lines shown.
+	dashboardLogLines = 6
+
+	// dashboardLineWidth is the width at which log lines are cut.
+	dashboardLineWidth = 120
+)
+
+// Terminal control sequences used to draw the dashboard.
+const (
+	ansiHome       = "\x1b[H"
+	ansiClear      = "\x1b[2J"
+	ansiClearLine  = "\x1b[K"
+	ansiClearBelow = "\x1b[J"
+	ansiHideCursor = "\x1b[?25l"
+	ansiShowCursor = "\x1b[?25h"
+)
+
+// sparkChars are the bars of a sparkline, from lowest to highest.
+var sparkChars = []rune("▁▂▃▄▅▆▇█")
+
+// ansiEscape matches the color sequences in console log output.
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// Dashboard is a live terminal view of running workloads, redrawn every
+// second, with per-query throughput and latency sparklines. Log lines
+// written to the dashboard are shown in its log pane, so that the log
+// output can be redirected to it while it runs.
+type Dashboard struct {
+	out   io.Writer
+	start time.Time
+	views []*dashboardView
+
+	logsMu sync.Mutex
+	logs   []string
+}
+
+// dashboardView holds the recent history of a workload.
+type dashboardView struct {
+	e           *Executor
+	lastTime    time.Time
+	lastTotal   int64
+	lastLatency HistogramSnapshot
+	qps         float64
+	p95         float64 // milliseconds
+	queries     map[string]*queryHistory
+}
+
+// queryHistory holds the recent throughput and latency of a query type.
+type queryHistory struct {
+	lastCount   int64
+	lastLatency HistogramSnapshot
+	qps         []float64
+	p95         []float64 // milliseconds
+}
+
+// NewDashboard creates a dashboard for the executors' workloads, drawn on
+// out.
+func NewDashboard(out io.Writer, executors ...*Executor) *Dashboard {
+	now := time.Now()
+	d := &Dashboard{out: out, start: now}
+	for _, e := range executors {
+		d.views = append(d.views, &dashboardView{
+			e:           e,
+			lastTime:    now,
+			lastTotal:   e.totalQueries.Load(),
+			lastLatency: e.latency.snapshot(),
+			queries:     make(map[string]*queryHistory),
+		})
+	}
+	return d
+}
+
+// Write adds log output to the dashboard's log pane.
+func (d *Dashboard) Write(p []byte) (int, error) {
+	d.logsMu.Lock()
+	defer d.logsMu.Unlock()
+
+	text := ansiEscape.ReplaceAllString(string(p), "")
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		d.logs = append(d.logs, truncate(line, dashboardLineWidth))
+	}
+	if n := len(d.logs); n > dashboardLogLines {
+		d.logs = append([]string{}, d.logs[n-dashboardLogLines:]...)
+	}
+	return len(p), nil
+}
+
+// Run redraws the dashboard every second until the context is cancelled,
+// then draws it a last time and leaves the cursor below it.
+func (d *Dashboard) Run(ctx context.Context) {
+	fmt.Fprint(d.out, ansiHideCursor+ansiClear)
+	defer fmt.Fprint(d.out, ansiShowCursor)
+
+	ticker := time.NewTicker(dashboardRefresh)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			d.draw(time.Now())
+			return
+		case now := <-ticker.C:
+			d.draw(now)
+		}
+	}
+}
+
+// draw updates the history and redraws the dashboard from the top left
+// of the terminal.
+func (d *Dashboard) draw(now time.Time) {
+	d.update(now)
+	fmt.Fprint(d.out, ansiHome+d.render(now)+ansiClearBelow)
+}
+
+// update adds the throughput and latency since the previous refresh to
+// the history of each workload and query type.
+func (d *Dashboard) update(now time.Time) {
+	for _, v := range d.views {
+		e := v.e
+		elapsed := now.Sub(v.lastTime).Seconds()
+		if elapsed <= 0 {
+			continue
+		}
+
+		total := e.totalQueries.Load()
+		latency := e.latency.snapshot()
+		v.qps = float64(total-v.lastTotal) / elapsed
+		v.p95 = durationMs(latency.Sub(v.lastLatency).Percentile(95))
+		v.lastTotal, v.lastLatency, v.lastTime = total, latency, now
+
+		e.queryMetrics.Range(func(key, value interface{}) bool {
+			m := value.(*queryMetric)
+			h := v.queries[key.(string)]
+			if h == nil {
+				h = &queryHistory{}
+				v.queries[key.(string)] = h
+			}
+
+			count := m.count.Load()
+			latency := m.latency.snapshot()
+			h.qps = appendHistory(h.qps, float64(count-h.lastCount)/elapsed)
+			h.p95 = appendHistory(h.p95, durationMs(latency.Sub(h.lastLatency).Percentile(95)))
+			h.lastCount, h.lastLatency = count, latency
+			return true
+		})
+	}
+}
+
+// appendHistory adds a value to a sparkline's history, dropping the
+// oldest value once the history is full.
+func appendHistory(history []float64, value float64) []float64 {
+	history = append(history, value)
+	if len(history) > sparklineWidth {
+		history = history[len(history)-sparklineWidth:]
+	}
+	return history
+}
+
+// render returns the dashboard's text. Each line clears the rest of the
+// terminal line.
+func (d *Dashboard) render(now time.Time) string {
+	var b strings.Builder
+	line := func(format string, args ...any) {
+		fmt.Fprintf(&b, format, args...)
+		b.WriteString(ansiClearLine + "\n")
+	}
+
+	line("pgedge-loadgen  running for %s  (Ctrl+C to stop)", now.Sub(d.start).Truncate(time.Second))
+	for _, v := range d.views {
+		line("")
+		v.render(line, now)
+	}
+
+	line("")
+	line("Log")
+	d.logsMu.Lock()
+	for _, l := range d.logs {
+		line("  %s", l)
+	}
+	d.logsMu.Unlock()
+	return b.String()
+}
+
+// render adds a workload's section to the dashboard.
+func (v *dashboardView) render(line func(string, ...any), now time.Time) {
+	e := v.e
+
+	title := e.app.Name()
+	if e.schema != "" {
+		title += " (schema " + e.schema + ")"
+	}
+	line("%s  profile %s  mode %s", title, e.profile.Name(), e.connectionMode)
+
+	activityLevel := e.activityLevel(now)
+	status := fmt.Sprintf("  Activity  %3.0f%% %s  workers %d",
+		activityLevel*100, bar(activityLevel, 10), e.workers.Load())
+	if e.sessions {
+		status += fmt.Sprintf("  sessions %d active, %d total",
+			e.activeSessions.Load(), e.totalSessions.Load())
+	}
+	if phase := e.currentPhase(); phase != "" {
+		status += "  phase " + phase
+	}
+	line("%s", status)
+	line("  Queries   %d total  %.1f qps  p95 %.2f ms  %d errors  %d timeouts",
+		e.totalQueries.Load(), v.qps, v.p95, e.failedQueries.Load(), e.timedOutQueries.Load())
+
+	// Per-query throughput and latency
+	line("")
+	line("  %-22s %8s  %-*s %9s  %-*s %7s %8s", "Query", "qps", sparklineWidth, "Throughput",
+		"p95 ms", sparklineWidth, "Latency (p95)", "Errors", "Timeouts")
+	names := make([]string, 0, len(v.queries))
+	for name := range v.queries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		h := v.queries[name]
+		m := e.getOrCreateQueryMetric(name)
+		line("  %-22s %8.1f  %s %9.2f  %s %7d %8d", truncate(name, 22), last(h.qps),
+			sparkline(h.qps, sparklineWidth), last(h.p95), sparkline(h.p95, sparklineWidth),
+			m.errors.Load(), m.timeouts.Load())
+	}
+
+	line("")
+	line("  Errors    %s", v.errorSummary())
+	line("  Cleanup   %s", v.cleanupSummary())
+}
+
+// errorSummary describes the failed and timed-out queries by failure
+// class, the retries by error class and the lost connections.
+func (v *dashboardView) errorSummary() string {
+	e := v.e
+	var parts []string
+
+	failureCounts := e.failureCounts()
+	for _, class := range failureClasses {
+		if n := failureCounts[class]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, class))
+		}
+	}
+
+	retryCounts, _ := e.retryCounts()
+	for _, class := range retryClasses {
+		if n := retryCounts[class]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s retries", n, class))
+		}
+	}
+	if n := e.reconnects.Load(); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d reconnects", n))
+	}
+	if n := e.connectErrors.Load(); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d failed connects", n))
+	}
+	if e.availabilityMode {
+		if n := e.downWorkers(); n > 0 {
+			parts = append(parts, fmt.Sprintf("%d workers down", n))
+		}
+	}
+
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// cleanupSummary describes the size maintenance activity.
+func (v *dashboardView) cleanupSummary() string {
+	e := v.e
+	if !e.maintainsSize() {
+		return "disabled"
+	}
+	lastCleanup := e.lastCleanupNs.Load()
+	if lastCleanup == 0 {
+		return fmt.Sprintf("first check in %s", e.cleanupInterval)
+	}
+	return fmt.Sprintf("%d rows deleted, last check %s deleted %d",
+		e.totalDeleted.Load(), time.Unix(0, lastCleanup).Format(time.TimeOnly), e.lastDeleted.Load())
+}
+
+// sparkline draws the values as a line of bars scaled to the largest
+// value, right-aligned in width characters. Zero values are blank.
+func sparkline(values []float64, width int) string {
+	var peak float64
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+
+	spark := []rune(strings.Repeat(" ", width-len(values)))
+	for _, v := range values {
+		if v <= 0 || peak == 0 {
+			spark = append(spark, ' ')
+			continue
+		}
+		index := int(v / peak * float64(len(sparkChars)-1))
+		spark = append(spark, sparkChars[index])
+	}
+	return string(spark)
+}
+
+// bar draws a fraction between 0 and 1 as a bar width characters wide.
+func bar(fraction float64, width int) string {
+	filled := int(min(max(fraction, 0), 1)*float64(width) + 0.5)
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+// last returns the most recent value of a history, or 0 if it is empty.
+func last(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	return values[len(values)-1]
+}
+
+// truncate shortens s to at most n characters.
+func truncate(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n])
+	}
+	return s
+}
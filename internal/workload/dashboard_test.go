@@ -0,0 +1,108 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package workload
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pgEdge/pgedge-loadgen/internal/apps"
+)
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		width  int
+		want   string
+	}{
+		{"empty", nil, 3, "   "},
+		{"right aligned", []float64{1, 2}, 4, "  ▄█"},
+		{"scaled to peak", []float64{0, 7, 14, 28}, 4, " ▂▄█"},
+		{"all zero", []float64{0, 0}, 2, "  "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sparkline(tt.values, tt.width); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDashboardRender(t *testing.T) {
+	e := newTestExecutor(t, ExecutorConfig{})
+	d := NewDashboard(&bytes.Buffer{}, e)
+
+	e.recordResult(apps.QueryResult{QueryName: "new_order", Duration: int64(2 * time.Millisecond)})
+	e.recordResult(apps.QueryResult{QueryName: "new_order", Duration: int64(4 * time.Millisecond)})
+	e.recordResult(apps.QueryResult{QueryName: "payment", Duration: int64(time.Millisecond),
+		Error: errors.New("boom")})
+
+	now := d.start.Add(2 * time.Second)
+	d.update(now)
+	out := d.render(now)
+
+	for _, want := range []string{
+		"running for 2s",
+		"wholesale  profile global",
+		"3 total  1.5 qps",
+		"new_order",
+		"Errors    1 client",
+		"Cleanup   disabled",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected dashboard to contain %q, got:\n%s", want, out)
+		}
+	}
+	if h := d.views[0].queries["new_order"]; h == nil || len(h.qps) != 1 || h.qps[0] != 1 {
+		t.Errorf("Expected new_order throughput history [1], got %+v", h)
+	}
+}
+
+func TestDashboardLog(t *testing.T) {
+	d := NewDashboard(&bytes.Buffer{})
+
+	// Log colors are removed
+	d.Write([]byte("\x1b[90m10:00:00\x1b[0m \x1b[32mINF\x1b[0m Starting\n"))
+	if len(d.logs) != 1 || d.logs[0] != "10:00:00 INF Starting" {
+		t.Errorf("Expected log line without colors, got %q", d.logs)
+	}
+
+	// Only the most recent lines are kept
+	for range dashboardLogLines {
+		d.Write([]byte("line\n"))
+	}
+	d.Write([]byte("10:00:01 INF Stopped\n"))
+	if len(d.logs) != dashboardLogLines {
+		t.Fatalf("Expected %d log lines, got %d", dashboardLogLines, len(d.logs))
+	}
+	if got := d.logs[len(d.logs)-1]; got != "10:00:01 INF Stopped" {
+		t.Errorf("Expected the last log line, got %q", got)
+	}
+}
+
+func TestDashboardRun(t *testing.T) {
+	var out bytes.Buffer
+	d := NewDashboard(&out)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	d.Run(ctx)
+
+	if got := out.String(); !strings.HasPrefix(got, ansiHideCursor) || !strings.HasSuffix(got, ansiShowCursor) {
+		t.Errorf("Expected the cursor to be hidden and shown again, got %q", got)
+	}
+}
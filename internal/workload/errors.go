@@ -0,0 +1,199 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package workload
+
+import (
+	"errors"
+	"io"
+	"net"
+	"sort"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog"
+
+	"github.com/pgEdge/pgedge-loadgen/internal/report"
+)
+
+// Failure classes of failed and timed-out queries, in addition to the
+// retry classes.
+const (
+	classUniqueViolation = "unique_violation"
+	classTimeout         = "timeout"
+	classServer          = "server" // other errors reported by the server
+	classClient          = "client" // errors raised by the client
+)
+
+// failureClasses lists the failure classes, in the order they are
+// reported.
+var failureClasses = []string{
+	classSerializationFailure, classDeadlock, classUniqueViolation,
+	classConnection, classTimeout, classServer, classClient,
+}
+
+const (
+	// maxErrorSamples is the number of distinct error messages kept as
+	// samples for the summary.
+	maxErrorSamples = 20
+
+	// errorSampleLength is the number of characters of an error message
+	// that are kept in a sample.
+	errorSampleLength = 300
+)
+
+// errorSample is a distinct error message seen during the run, with the
+// first query that failed with it.
+type errorSample struct {
+	class   string
+	query   string
+	message string
+	count   int64
+}
+
+// failureClass returns the failure class of a failed or timed-out query,
+// from the SQLSTATE of server errors. Client errors caused by a broken
+// connection are connection failures.
+func failureClass(err error) string {
+	if isTimeout(err) {
+		return classTimeout
+	}
+	if class := errorClass(err, false); class != "" {
+		return class
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		if pgErr.Code == "23505" {
+			return classUniqueViolation
+		}
+		return classServer
+	}
+
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	if errors.As(err, &connectErr) || errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, net.ErrClosed) {
+		return classConnection
+	}
+	return classClient
+}
+
+// recordFailure counts a failed or timed-out query by failure class, and
+// keeps its message as a sample if it has not been seen before. The
+// first occurrence of each sampled message is logged.
+func (e *Executor) recordFailure(metric *queryMetric, query string, err error) string {
+	class := failureClass(err)
+	metric.failures[class].Add(1)
+
+	message := truncate(err.Error(), errorSampleLength)
+	e.errorSamplesMu.Lock()
+	defer e.errorSamplesMu.Unlock()
+	for _, s := range e.errorSamples {
+		if s.message == message {
+			s.count++
+			return class
+		}
+	}
+	if len(e.errorSamples) < maxErrorSamples {
+		e.errorSamples = append(e.errorSamples, &errorSample{
+			class:   class,
+			query:   query,
+			message: message,
+			count:   1,
+		})
+		e.log.Warn().
+			Str("query", query).
+			Str("class", class).
+			Str("error", message).
+			Msg("New query error")
+	}
+	return class
+}
+
+// failureCounts returns the number of failed and timed-out queries by
+// failure class for a query type, leaving out the classes with none.
+func (m *queryMetric) failureCounts() map[string]int64 {
+	var counts map[string]int64
+	for _, class := range failureClasses {
+		if n := m.failures[class].Load(); n > 0 {
+			if counts == nil {
+				counts = make(map[string]int64)
+			}
+			counts[class] = n
+		}
+	}
+	return counts
+}
+
+// failureCounts returns the number of failed and timed-out queries by
+// failure class across all query types, leaving out the classes with
+// none.
+func (e *Executor) failureCounts() map[string]int64 {
+	var counts map[string]int64
+	e.queryMetrics.Range(func(_, value interface{}) bool {
+		for class, n := range value.(*queryMetric).failureCounts() {
+			if counts == nil {
+				counts = make(map[string]int64)
+			}
+			counts[class] += n
+		}
+		return true
+	})
+	return counts
+}
+
+// errorSampleReport returns the sampled error messages, the most frequent
+// first.
+func (e *Executor) errorSampleReport() []report.ErrorSample {
+	e.errorSamplesMu.Lock()
+	defer e.errorSamplesMu.Unlock()
+
+	var samples []report.ErrorSample
+	for _, s := range e.errorSamples {
+		samples = append(samples, report.ErrorSample{
+			Class:   s.class,
+			Query:   s.query,
+			Message: s.message,
+			Count:   s.count,
+		})
+	}
+	sort.SliceStable(samples, func(i, j int) bool {
+		return samples[i].Count > samples[j].Count
+	})
+	return samples
+}
+
+// addFailureFields adds the failure counts by class to a log event.
+func addFailureFields(logEvent *zerolog.Event, counts map[string]int64) *zerolog.Event {
+	for _, class := range failureClasses {
+		if n := counts[class]; n > 0 {
+			logEvent = logEvent.Int64(class+"_failures", n)
+		}
+	}
+	return logEvent
+}
+
+// logFailures logs the failures by class and the sampled error messages.
+func (e *Executor) logFailures() {
+	counts := e.failureCounts()
+	if len(counts) == 0 {
+		return
+	}
+	addFailureFields(e.log.Info(), counts).Msg("Failures by class:")
+	e.log.Info().Msg("Distinct errors:")
+	for _, s := range e.errorSampleReport() {
+		e.log.Info().
+			Str("class", s.Class).
+			Str("query", s.Query).
+			Int64("count", s.Count).
+			Str("error", s.Message).
+			Msg("")
+	}
+}
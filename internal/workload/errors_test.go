@@ -0,0 +1,117 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package workload
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/pgEdge/pgedge-loadgen/internal/apps"
+)
+
+func TestFailureClass(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"serialization failure", &pgconn.PgError{Code: "40001"}, classSerializationFailure},
+		{"deadlock", fmt.Errorf("new_order: %w", &pgconn.PgError{Code: "40P01"}), classDeadlock},
+		{"unique violation", &pgconn.PgError{Code: "23505"}, classUniqueViolation},
+		{"admin shutdown", &pgconn.PgError{Code: "57P01"}, classConnection},
+		{"connection failure", &pgconn.PgError{Code: "08006"}, classConnection},
+		{"connection lost", fmt.Errorf("payment: %w", io.ErrUnexpectedEOF), classConnection},
+		{"connect failed", &pgconn.ConnectError{}, classConnection},
+		{"query timeout", &queryTimeoutError{timeout: time.Second}, classTimeout},
+		{"lock timeout", &pgconn.PgError{Code: "55P03", Message: "canceling statement due to lock timeout"}, classTimeout},
+		{"nowait", &pgconn.PgError{Code: "55P03", Message: "could not obtain lock on row"}, classServer},
+		{"undefined table", &pgconn.PgError{Code: "42P01"}, classServer},
+		{"client error", errors.New("cannot encode argument"), classClient},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := failureClass(tt.err); got != tt.want {
+				t.Errorf("Expected class %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRecordResultFailures(t *testing.T) {
+	e := newTestExecutor(t, ExecutorConfig{})
+
+	deadlock := &pgconn.PgError{Code: "40P01", Message: "deadlock detected"}
+	duplicate := &pgconn.PgError{Code: "23505", Message: "duplicate key value violates unique constraint"}
+	e.recordResult(apps.QueryResult{QueryName: "new_order", Error: deadlock})
+	e.recordResult(apps.QueryResult{QueryName: "payment", Error: deadlock})
+	e.recordResult(apps.QueryResult{QueryName: "new_order", Error: deadlock})
+	e.recordResult(apps.QueryResult{QueryName: "new_order", Error: duplicate})
+	e.recordResult(apps.QueryResult{QueryName: "delivery", Error: &queryTimeoutError{timeout: time.Second}})
+	e.recordResult(apps.QueryResult{QueryName: "delivery"})
+
+	r := e.Report()
+	want := map[string]int64{classDeadlock: 3, classUniqueViolation: 1, classTimeout: 1}
+	if len(r.Summary.Failures) != len(want) {
+		t.Errorf("Expected failures %v, got %v", want, r.Summary.Failures)
+	}
+	for class, n := range want {
+		if r.Summary.Failures[class] != n {
+			t.Errorf("Expected %d %s failures, got %d", n, class, r.Summary.Failures[class])
+		}
+	}
+	for _, q := range r.Queries {
+		if q.Name == "new_order" && (q.Failures[classDeadlock] != 2 || q.Failures[classUniqueViolation] != 1) {
+			t.Errorf("Unexpected new_order failures: %v", q.Failures)
+		}
+	}
+
+	// Distinct messages are sampled once, the most frequent first
+	if len(r.ErrorSamples) != 3 {
+		t.Fatalf("Expected 3 error samples, got %+v", r.ErrorSamples)
+	}
+	if s := r.ErrorSamples[0]; s.Class != classDeadlock || s.Query != "new_order" || s.Count != 3 {
+		t.Errorf("Unexpected first error sample: %+v", s)
+	}
+
+	families := gatherMetrics(t, e)
+	var deadlocks float64
+	for _, m := range families["pgedge_loadgen_query_failures_total"].GetMetric() {
+		for _, l := range m.GetLabel() {
+			if l.GetName() == "class" && l.GetValue() == classDeadlock {
+				deadlocks += m.GetCounter().GetValue()
+			}
+		}
+	}
+	if deadlocks != 3 {
+		t.Errorf("Expected 3 deadlock failures metric, got %v", deadlocks)
+	}
+}
+
+func TestErrorSamplesLimit(t *testing.T) {
+	e := newTestExecutor(t, ExecutorConfig{})
+
+	for i := range maxErrorSamples + 5 {
+		e.recordResult(apps.QueryResult{QueryName: "payment", Error: fmt.Errorf("error %d", i)})
+	}
+
+	r := e.Report()
+	if len(r.ErrorSamples) != maxErrorSamples {
+		t.Errorf("Expected %d error samples, got %d", maxErrorSamples, len(r.ErrorSamples))
+	}
+	if r.Summary.Failures[classClient] != maxErrorSamples+5 {
+		t.Errorf("Expected all failures to be counted, got %v", r.Summary.Failures)
+	}
+}
@@ -14,46 +14,79 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand/v2"
+	"os"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
 
-	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
 
 	"github.com/pgEdge/pgedge-loadgen/internal/apps"
 	"github.com/pgEdge/pgedge-loadgen/internal/db"
 	"github.com/pgEdge/pgedge-loadgen/internal/logging"
+	"github.com/pgEdge/pgedge-loadgen/internal/report"
 	"github.com/pgEdge/pgedge-loadgen/internal/workload/profiles"
+	"github.com/pgEdge/pgedge-loadgen/pkg/version"
 )
 
 // ExecutorConfig holds configuration for the workload executor.
 type ExecutorConfig struct {
 	ConnString         string // Connection string for creating per-worker connections
+	Schema             string // Schema holding the app's tables (empty = default search_path)
 	App                apps.App
 	Connections        int
 	Profile            string
 	Timezone           string
 	ReportInterval     int
 	ConnectionMode     string
-	SessionMinDuration int   // seconds
-	SessionMaxDuration int   // seconds
-	ThinkTimeMin       int   // milliseconds
-	ThinkTimeMax       int   // milliseconds
-	MaintainSize       bool  // Enable automatic cleanup to maintain target size
-	TargetSize         int64 // Target database size in bytes
-	CleanupInterval    int   // Cleanup check interval in seconds (default 300)
+	PoolSize           int       // Connections in a pool shared by the workers (0 = one per worker)
+	ChurnQueries       int       // Queries per connection in churn mode (0 = one connection per session)
+	Sessions           bool      // Workers simulate user sessions (session mode, or churn mode per session)
+	SessionMinDuration int       // seconds
+	SessionMaxDuration int       // seconds
+	ThinkTimeMin       int       // milliseconds
+	ThinkTimeMax       int       // milliseconds
+	MaintainSize       bool      // Enable automatic cleanup to maintain target size
+	TargetSize         int64     // Target database size in bytes
+	CleanupInterval    int       // Cleanup check interval in seconds (default 300)
+	TargetQPS          float64   // Open-loop arrival rate at full activity (0 = closed loop)
+	TimeScale          float64   // Profile playback speed relative to real time (0 = 1x)
+	StartTime          time.Time // Profile time at the start of the run (zero = now)
+	Seed               uint64    // Base seed for the workers' randomness (0 = random)
+	Phases             []Phase   // Load phase schedule replacing Connections (optional)
+	Reconnect          ReconnectPolicy
+	Retry              RetryPolicy
+	Availability       bool                     // Track outages and time to recover per node
+	QueryTimeout       time.Duration            // Client-side timeout of each query (0 = none)
+	QueryTimeouts      map[string]time.Duration // Query timeout overrides by query name (0 = none)
+	Settings           map[string]string        // Server settings for the workers' connections
+	ServerStats        bool                     // Sample the server's statistics
+	TimeSeriesFile     string                   // CSV file to write each report interval to
 }
 
 // Executor manages the workload execution.
 type Executor struct {
 	connString     string
+	schema         string
+	settings       map[string]string // server settings for the workers' connections
 	app            apps.App
 	connections    int
 	profile        profiles.Profile
+	clock          *profileClock
+	seed           uint64 // base seed for the workers' randomness
 	reportInterval time.Duration
+	timeSeriesFile string
+	log            zerolog.Logger // tagged with the app and schema if a schema is set
 
 	// Connection mode settings
 	connectionMode     string
+	poolSize           int
+	churnQueries       int
+	sessions           bool                         // workers simulate user sessions
+	pool               atomic.Pointer[pgxpool.Pool] // pool shared by the workers (pool size set)
 	sessionMinDuration time.Duration
 	sessionMaxDuration time.Duration
 	thinkTimeMin       time.Duration
@@ -64,28 +97,90 @@ type Executor struct {
 	targetSize      int64
 	cleanupInterval time.Duration
 
+	// Target rate (open-loop) settings
+	targetQPS float64
+	arrivals  chan time.Time
+
+	// Query timeout settings
+	queryTimeout  time.Duration
+	queryTimeouts map[string]time.Duration
+
+	// Reconnect and retry settings
+	reconnect        ReconnectPolicy
+	retry            RetryPolicy
+	availabilityMode bool
+
+	// Load phase settings
+	phases  []Phase
+	phase   atomic.Int32 // index of the running phase
+	workers atomic.Int64 // number of running workers
+
 	// Metrics
 	totalQueries    atomic.Int64
 	successQueries  atomic.Int64
 	failedQueries   atomic.Int64
+	timedOutQueries atomic.Int64
 	totalDurationNs atomic.Int64
 	startTime       time.Time
+	endTime         time.Time
 
 	// Session metrics (session mode only)
 	totalSessions  atomic.Int64
 	activeSessions atomic.Int64
 
 	// Cleanup metrics
-	totalDeleted atomic.Int64
+	totalDeleted  atomic.Int64
+	lastCleanupNs atomic.Int64 // time of the last size check (Unix nanoseconds)
+	lastDeleted   atomic.Int64 // rows deleted by the last size check
+
+	// Pool metrics (pool size set)
+	acquireLatency *histogram
+
+	// Connection metrics
+	connectLatency *histogram
+	connectErrors  atomic.Int64
+
+	// Reconnect and retry metrics
+	reconnects atomic.Int64
+	retries    map[string]*atomic.Int64 // by error class
+
+	// Server statistics (server stats enabled only)
+	serverStats *serverStatsCollector
+
+	// Outages (availability mode only)
+	outagesMu   sync.Mutex
+	outages     []workerOutage // finished worker outages
+	workersDown int            // workers currently in an outage
+	downSince   time.Time      // start of the current outage
+
+	// Schedule metrics (target rate mode only)
+	totalLagNs      atomic.Int64
+	maxLagNs        atomic.Int64
+	droppedArrivals atomic.Int64
+
+	// Latency histogram across all query types
+	latency *histogram
 
 	// Query type metrics
 	queryMetrics sync.Map // map[string]*queryMetric
+
+	// Distinct error messages
+	errorSamplesMu sync.Mutex
+	errorSamples   []*errorSample
+
+	// Per-interval and per-phase statistics
+	intervalsMu  sync.Mutex
+	intervals    []report.Interval
+	phaseResults []phaseResult
 }
 
 type queryMetric struct {
 	count      atomic.Int64
 	durationNs atomic.Int64
 	errors     atomic.Int64
+	timeouts   atomic.Int64
+	failures   map[string]*atomic.Int64 // errors and timeouts by failure class
+	latency    *histogram
 }
 
 // NewExecutor creates a new workload executor.
@@ -107,13 +202,67 @@ func NewExecutor(cfg ExecutorConfig) (*Executor, error) {
 		cleanupInterval = 300
 	}
 
-	return &Executor{
+	// Default the reconnect backoff
+	reconnect := cfg.Reconnect
+	if reconnect.InitialBackoff <= 0 {
+		reconnect.InitialBackoff = defaultInitialBackoff
+	}
+	if reconnect.MaxBackoff <= 0 {
+		reconnect.MaxBackoff = defaultMaxBackoff
+	}
+	reconnect.MaxBackoff = max(reconnect.MaxBackoff, reconnect.InitialBackoff)
+
+	retries := make(map[string]*atomic.Int64, len(retryClasses))
+	for _, class := range retryClasses {
+		retries[class] = &atomic.Int64{}
+	}
+
+	// Pick a random seed if none was given
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+
+	// With a phase schedule, the peak number of workers replaces Connections
+	connections := cfg.Connections
+	if len(cfg.Phases) > 0 {
+		connections = 0
+		for _, p := range cfg.Phases {
+			connections = max(connections, p.Connections)
+		}
+	}
+
+	// Arrival queue for target rate mode
+	var arrivals chan time.Time
+	if cfg.TargetQPS > 0 {
+		arrivals = make(chan time.Time, max(connections*arrivalQueueFactor, minArrivalQueue))
+	}
+
+	// Tag log lines with the workload when running in a schema
+	log := logging.Logger
+	if cfg.Schema != "" {
+		log = log.With().
+			Str("app", cfg.App.Name()).
+			Str("schema", cfg.Schema).
+			Logger()
+	}
+
+	e := &Executor{
 		connString:         cfg.ConnString,
+		schema:             cfg.Schema,
+		settings:           cfg.Settings,
 		app:                cfg.App,
-		connections:        cfg.Connections,
+		connections:        connections,
 		profile:            profile,
+		clock:              newProfileClock(cfg.StartTime, cfg.TimeScale),
+		seed:               seed,
 		reportInterval:     time.Duration(cfg.ReportInterval) * time.Second,
+		timeSeriesFile:     cfg.TimeSeriesFile,
+		log:                log,
 		connectionMode:     connectionMode,
+		poolSize:           cfg.PoolSize,
+		churnQueries:       cfg.ChurnQueries,
+		sessions:           cfg.Sessions,
 		sessionMinDuration: time.Duration(cfg.SessionMinDuration) * time.Second,
 		sessionMaxDuration: time.Duration(cfg.SessionMaxDuration) * time.Second,
 		thinkTimeMin:       time.Duration(cfg.ThinkTimeMin) * time.Millisecond,
@@ -121,72 +270,191 @@ func NewExecutor(cfg ExecutorConfig) (*Executor, error) {
 		maintainSize:       cfg.MaintainSize,
 		targetSize:         cfg.TargetSize,
 		cleanupInterval:    time.Duration(cleanupInterval) * time.Second,
-	}, nil
+		targetQPS:          cfg.TargetQPS,
+		arrivals:           arrivals,
+		reconnect:          reconnect,
+		retry:              cfg.Retry,
+		retries:            retries,
+		availabilityMode:   cfg.Availability,
+		queryTimeout:       cfg.QueryTimeout,
+		queryTimeouts:      cfg.QueryTimeouts,
+		phases:             cfg.Phases,
+		latency:            newHistogram(),
+		acquireLatency:     newHistogram(),
+		connectLatency:     newHistogram(),
+	}
+	if cfg.ServerStats {
+		e.serverStats = newServerStatsCollector(e)
+	}
+	return e, nil
 }
 
-// Run starts the workload execution and blocks until context is cancelled.
+// Run starts the workload execution and blocks until context is cancelled,
+// or until the last phase ends if the run has a phase schedule.
 func (e *Executor) Run(ctx context.Context) error {
 	e.startTime = time.Now()
+	e.clock.restart(e.startTime)
 
-	logging.Info().
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	logEvent := e.log.Info().
 		Str("mode", e.connectionMode).
 		Int("connections", e.connections).
-		Msg("Starting workload execution")
-
-	// Start workers
-	var wg sync.WaitGroup
-	for i := 0; i < e.connections; i++ {
-		wg.Add(1)
-		go func(workerID int) {
-			defer wg.Done()
-			if e.connectionMode == "session" {
-				e.sessionWorker(ctx, workerID)
-			} else {
-				e.poolWorker(ctx, workerID)
-			}
-		}(i)
+		Uint64("seed", e.seed)
+	if e.poolSize > 0 {
+		logEvent = logEvent.Int("pool_size", e.poolSize)
+	}
+	if e.connectionMode == "churn" && e.churnQueries > 0 {
+		logEvent = logEvent.Int("churn_queries", e.churnQueries)
+	}
+	if len(e.phases) > 0 {
+		logEvent = logEvent.Int("phases", len(e.phases))
+	}
+	if e.targetQPS > 0 {
+		logEvent = logEvent.Float64("target_qps", e.targetQPS)
+	}
+	if e.availabilityMode {
+		logEvent = logEvent.Bool("availability", true)
+	}
+	if e.queryTimeout > 0 {
+		logEvent = logEvent.Dur("query_timeout", e.queryTimeout)
+	}
+	if e.serverStats != nil {
+		logEvent = logEvent.Bool("server_stats", true)
+	}
+	if e.timeSeriesFile != "" {
+		logEvent = logEvent.Str("timeseries_file", e.timeSeriesFile)
+	}
+	if e.clock.virtual() {
+		logEvent = logEvent.
+			Float64("time_scale", e.clock.scale).
+			Time("profile_start", e.clock.at(time.Now()))
+	}
+	logEvent.Msg("Starting workload execution")
+
+	// Create the time series file, before any connections are opened
+	var timeSeries *report.TimeSeriesWriter
+	if e.timeSeriesFile != "" {
+		f, err := os.Create(e.timeSeriesFile)
+		if err != nil {
+			return fmt.Errorf("failed to create time series file: %w", err)
+		}
+		defer f.Close()
+		if timeSeries, err = report.NewTimeSeriesWriter(f, e.queryNames()); err != nil {
+			return fmt.Errorf("failed to write time series file: %w", err)
+		}
+	}
+
+	// Create the pool shared by the workers
+	if e.poolSize > 0 {
+		pool, err := db.Connect(ctx, e.connString, e.schema, e.settings, int32(e.poolSize), "pool")
+		if err != nil {
+			return err
+		}
+		e.pool.Store(pool)
+		defer pool.Close()
 	}
 
-	// Start reporter
+	// Take the first sample of the server's statistics
+	if e.serverStats != nil {
+		e.serverStats.start(ctx)
+	}
+
+	// Start the arrival scheduler in target rate mode
+	if e.targetQPS > 0 {
+		go e.scheduler(ctx)
+	}
+
+	// Start reporter, writing the time series file if requested. It is
+	// stopped once the workers have, so that its last interval covers
+	// their final queries.
+	reporterCtx, stopReporter := context.WithCancel(context.WithoutCancel(ctx))
+	defer stopReporter()
+	reporterDone := make(chan struct{})
 	if e.reportInterval > 0 {
-		go e.reporter(ctx)
+		go func() {
+			defer close(reporterDone)
+			e.reporter(reporterCtx, timeSeries)
+		}()
+	} else {
+		close(reporterDone)
 	}
 
 	// Start size maintenance if enabled and app supports it
-	if e.maintainSize && e.targetSize > 0 {
-		if _, ok := e.app.(apps.SizeMaintainer); ok {
-			go e.cleaner(ctx)
-		}
+	if e.maintainsSize() {
+		go e.cleaner(ctx)
+	}
+
+	// Start workers, following the phase schedule if there is one
+	workers := &workerSet{e: e, ctx: ctx}
+	if len(e.phases) > 0 {
+		e.runPhases(ctx, workers)
+		workers.resize(0)
+		workers.wait()
+		cancel()
+	} else {
+		workers.resize(e.connections)
 	}
 
 	// Wait for all workers to complete
-	wg.Wait()
+	workers.wait()
+	e.endTime = time.Now()
+
+	// Stop the reporter before the time series file is closed
+	cancel()
+	stopReporter()
+	<-reporterDone
+
+	// Take the last sample of the server's statistics
+	if e.serverStats != nil {
+		e.serverStats.finish()
+	}
 
 	return nil
 }
 
-// poolWorker implements the pool connection mode where connections are
-// shared and reused rapidly, typical for web applications.
-func (e *Executor) poolWorker(ctx context.Context, id int) {
-	logging.Debug().Int("worker_id", id).Msg("Pool worker started")
+// worker runs a single worker in the configured mode until the context is
+// cancelled or the worker is retired by closing stop.
+func (e *Executor) worker(ctx context.Context, id int, stop <-chan struct{}) {
+	switch {
+	case e.targetQPS > 0:
+		e.rateWorker(ctx, id, stop)
+	case e.connectionMode == "session":
+		e.sessionWorker(ctx, id, stop)
+	case e.connectionMode == "churn":
+		e.churnWorker(ctx, id, stop)
+	default:
+		e.poolWorker(ctx, id, stop)
+	}
+}
 
-	// Create dedicated connection for this worker with unique application name
-	appNameSuffix := fmt.Sprintf("client %d", id+1)
-	conn, err := db.ConnectSingle(ctx, e.connString, appNameSuffix)
-	if err != nil {
-		logging.Error().Err(err).Int("worker_id", id).Msg("Failed to create worker connection")
+// poolWorker implements the pool connection mode where connections are
+// shared and reused rapidly, typical for web applications. The worker has
+// its own connection unless the workers share a pool.
+func (e *Executor) poolWorker(ctx context.Context, id int, stop <-chan struct{}) {
+	e.log.Debug().Int("worker_id", id).Msg("Pool worker started")
+
+	// Create dedicated connection for this worker, unless there is a pool
+	w := &workerConn{e: e, id: id}
+	if e.pool.Load() == nil && !w.connect(ctx, stop) {
 		return
 	}
-	defer conn.Close(ctx)
+	defer w.close(ctx)
+
+	queries := e.app.NewQueryExecutor(ctx, w.db(), e.workerSeed(id))
 
 	for {
 		select {
 		case <-ctx.Done():
-			logging.Debug().Int("worker_id", id).Msg("Pool worker stopped")
+			e.log.Debug().Int("worker_id", id).Msg("Pool worker stopped")
+			return
+		case <-stop:
+			e.log.Debug().Int("worker_id", id).Msg("Pool worker retired")
 			return
 		default:
 			// Get current activity level from profile
-			activityLevel := e.profile.GetActivityLevel(time.Now())
+			activityLevel := e.activityLevel(time.Now())
 
 			// Skip if activity level is very low
 			if activityLevel < 0.01 {
@@ -194,31 +462,11 @@ func (e *Executor) poolWorker(ctx context.Context, id int) {
 				continue
 			}
 
-			// Execute query using dedicated connection
-			result := e.app.ExecuteQueryConn(ctx, conn)
-
-			// Update metrics
-			e.totalQueries.Add(1)
-			e.totalDurationNs.Add(result.Duration)
-
-			metric := e.getOrCreateQueryMetric(result.QueryName)
-			metric.count.Add(1)
-			metric.durationNs.Add(result.Duration)
-
-			if result.Error != nil {
-				// Don't count context cancellation errors as failures
-				// (these occur at shutdown when run duration ends)
-				if !errors.Is(result.Error, context.Canceled) &&
-					!errors.Is(result.Error, context.DeadlineExceeded) {
-					e.failedQueries.Add(1)
-					metric.errors.Add(1)
-					logging.Debug().
-						Err(result.Error).
-						Str("query", result.QueryName).
-						Msg("Query failed")
-				}
-			} else {
-				e.successQueries.Add(1)
+			// Execute query using the worker's connection or the pool
+			result, ok := w.execute(ctx, stop, queries)
+			e.recordResult(result)
+			if !ok {
+				return
 			}
 
 			// Apply delay based on activity level
@@ -234,26 +482,30 @@ func (e *Executor) poolWorker(ctx context.Context, id int) {
 // sessionWorker implements the session connection mode where each worker
 // simulates a user session with think time between queries, typical for
 // desktop applications like trading terminals.
-func (e *Executor) sessionWorker(ctx context.Context, id int) {
-	logging.Debug().Int("worker_id", id).Msg("Session worker started")
+func (e *Executor) sessionWorker(ctx context.Context, id int, stop <-chan struct{}) {
+	e.log.Debug().Int("worker_id", id).Msg("Session worker started")
 
-	// Create dedicated connection for this worker with unique application name
-	appNameSuffix := fmt.Sprintf("client %d", id+1)
-	conn, err := db.ConnectSingle(ctx, e.connString, appNameSuffix)
-	if err != nil {
-		logging.Error().Err(err).Int("worker_id", id).Msg("Failed to create worker connection")
+	// Create dedicated connection for this worker
+	w := &workerConn{e: e, id: id}
+	if !w.connect(ctx, stop) {
 		return
 	}
-	defer conn.Close(ctx)
+	defer w.close(ctx)
+
+	queries := e.app.NewQueryExecutor(ctx, w.db(), e.workerSeed(id))
+	rng := rand.New(rand.NewPCG(e.workerSeed(id), 0))
 
 	for {
 		select {
 		case <-ctx.Done():
-			logging.Debug().Int("worker_id", id).Msg("Session worker stopped")
+			e.log.Debug().Int("worker_id", id).Msg("Session worker stopped")
+			return
+		case <-stop:
+			e.log.Debug().Int("worker_id", id).Msg("Session worker retired")
 			return
 		default:
 			// Get current activity level from profile
-			activityLevel := e.profile.GetActivityLevel(time.Now())
+			activityLevel := e.activityLevel(time.Now())
 
 			// Skip starting new sessions if activity level is very low
 			if activityLevel < 0.01 {
@@ -262,15 +514,20 @@ func (e *Executor) sessionWorker(ctx context.Context, id int) {
 			}
 
 			// Run a user session
-			e.runSession(ctx, id, conn, activityLevel)
+			if !e.runSession(ctx, w, queries, rng, stop, activityLevel) {
+				return
+			}
 		}
 	}
 }
 
-// runSession simulates a single user session with multiple queries and think time.
-func (e *Executor) runSession(ctx context.Context, workerID int, conn *pgx.Conn, activityLevel float64) {
+// runSession simulates a single user session with multiple queries and think
+// time. The session ends early if the worker is retired. It returns false if
+// the worker's connection was lost and could not be re-established.
+func (e *Executor) runSession(ctx context.Context, w *workerConn,
+	queries apps.QueryExecutor, rng *rand.Rand, stop <-chan struct{}, activityLevel float64) bool {
 	// Calculate session duration (randomized within range)
-	sessionDuration := e.randomDuration(e.sessionMinDuration, e.sessionMaxDuration)
+	sessionDuration := randomDuration(rng, e.sessionMinDuration, e.sessionMaxDuration)
 
 	// Scale session duration by activity level (lower activity = shorter sessions)
 	sessionDuration = time.Duration(float64(sessionDuration) * activityLevel)
@@ -282,8 +539,8 @@ func (e *Executor) runSession(ctx context.Context, workerID int, conn *pgx.Conn,
 	e.activeSessions.Add(1)
 	defer e.activeSessions.Add(-1)
 
-	logging.Debug().
-		Int("worker_id", workerID).
+	e.log.Debug().
+		Int("worker_id", w.id).
 		Dur("session_duration", sessionDuration).
 		Msg("Session started")
 
@@ -293,66 +550,94 @@ func (e *Executor) runSession(ctx context.Context, workerID int, conn *pgx.Conn,
 	for time.Now().Before(sessionEnd) {
 		select {
 		case <-ctx.Done():
-			return
+			return true
+		case <-stop:
+			return true
 		default:
 			// Execute query using dedicated connection
-			result := e.app.ExecuteQueryConn(ctx, conn)
-
-			// Update metrics
-			e.totalQueries.Add(1)
-			e.totalDurationNs.Add(result.Duration)
-
-			metric := e.getOrCreateQueryMetric(result.QueryName)
-			metric.count.Add(1)
-			metric.durationNs.Add(result.Duration)
-
-			if result.Error != nil {
-				// Don't count context cancellation errors as failures
-				// (these occur at shutdown when run duration ends)
-				if !errors.Is(result.Error, context.Canceled) &&
-					!errors.Is(result.Error, context.DeadlineExceeded) {
-					e.failedQueries.Add(1)
-					metric.errors.Add(1)
-					logging.Debug().
-						Err(result.Error).
-						Str("query", result.QueryName).
-						Msg("Query failed")
-				}
-			} else {
-				e.successQueries.Add(1)
+			result, ok := w.execute(ctx, stop, queries)
+			e.recordResult(result)
+			if !ok {
+				return false
 			}
 
 			// Apply think time between queries
-			thinkTime := e.randomDuration(e.thinkTimeMin, e.thinkTimeMax)
+			thinkTime := randomDuration(rng, e.thinkTimeMin, e.thinkTimeMax)
 			select {
 			case <-ctx.Done():
-				return
+				return true
+			case <-stop:
+				return true
 			case <-time.After(thinkTime):
 			}
 		}
 	}
 
-	logging.Debug().
-		Int("worker_id", workerID).
+	e.log.Debug().
+		Int("worker_id", w.id).
 		Msg("Session ended")
 
 	// Brief pause between sessions (simulates user logging out and back in)
 	select {
 	case <-ctx.Done():
+		return true
+	case <-stop:
+		return true
+	case <-time.After(randomDuration(rng, time.Second, 5*time.Second)):
+	}
+	return true
+}
+
+// recordResult updates the executor metrics with the outcome of a query.
+func (e *Executor) recordResult(result apps.QueryResult) {
+	// Nothing to record if no query was run
+	if result.QueryName == "" {
 		return
-	case <-time.After(e.randomDuration(time.Second, 5*time.Second)):
+	}
+
+	e.totalQueries.Add(1)
+	e.totalDurationNs.Add(result.Duration)
+
+	metric := e.getOrCreateQueryMetric(result.QueryName)
+	metric.count.Add(1)
+	metric.durationNs.Add(result.Duration)
+
+	e.latency.record(result.Duration)
+	metric.latency.record(result.Duration)
+
+	switch {
+	case result.Error == nil:
+		e.successQueries.Add(1)
+	case isTimeout(result.Error):
+		// Timeouts are counted separately from errors
+		e.timedOutQueries.Add(1)
+		metric.timeouts.Add(1)
+		e.recordFailure(metric, result.QueryName, result.Error)
+		e.log.Debug().
+			Err(result.Error).
+			Str("query", result.QueryName).
+			Msg("Query timed out")
+	case !errors.Is(result.Error, context.Canceled) &&
+		!errors.Is(result.Error, context.DeadlineExceeded):
+		// Don't count context cancellation errors as failures
+		// (these occur at shutdown when run duration ends)
+		e.failedQueries.Add(1)
+		metric.errors.Add(1)
+		class := e.recordFailure(metric, result.QueryName, result.Error)
+		e.log.Debug().
+			Err(result.Error).
+			Str("query", result.QueryName).
+			Str("class", class).
+			Msg("Query failed")
 	}
 }
 
 // randomDuration returns a random duration between min and max.
-func (e *Executor) randomDuration(min, max time.Duration) time.Duration {
+func randomDuration(rng *rand.Rand, min, max time.Duration) time.Duration {
 	if max <= min {
 		return min
 	}
-	delta := max - min
-	// Use simple random based on current time nanoseconds
-	randomNs := time.Now().UnixNano() % int64(delta)
-	return min + time.Duration(randomNs)
+	return min + time.Duration(rng.Int64N(int64(max-min)))
 }
 
 func (e *Executor) getOrCreateQueryMetric(name string) *queryMetric {
@@ -360,11 +645,50 @@ func (e *Executor) getOrCreateQueryMetric(name string) *queryMetric {
 		return m.(*queryMetric)
 	}
 
-	m := &queryMetric{}
+	m := &queryMetric{
+		failures: make(map[string]*atomic.Int64, len(failureClasses)),
+		latency:  newHistogram(),
+	}
+	for _, class := range failureClasses {
+		m.failures[class] = &atomic.Int64{}
+	}
 	actual, _ := e.queryMetrics.LoadOrStore(name, m)
 	return actual.(*queryMetric)
 }
 
+// addLatencyFields adds the reported latency percentiles and maximum of a
+// histogram snapshot to a log event.
+func addLatencyFields(logEvent *zerolog.Event, s HistogramSnapshot) *zerolog.Event {
+	for _, p := range reportedPercentiles {
+		logEvent = logEvent.Float64(p.name+"_latency_ms", durationMs(s.Percentile(p.value)))
+	}
+	return logEvent.Float64("max_latency_ms", durationMs(s.Max()))
+}
+
+// latencyStats converts a histogram snapshot to report latency statistics.
+func latencyStats(s HistogramSnapshot) report.Latency {
+	return report.Latency{
+		Mean: durationMs(s.Mean()),
+		P50:  durationMs(s.Percentile(50)),
+		P90:  durationMs(s.Percentile(90)),
+		P95:  durationMs(s.Percentile(95)),
+		P99:  durationMs(s.Percentile(99)),
+		P999: durationMs(s.Percentile(99.9)),
+		Max:  durationMs(s.Max()),
+	}
+}
+
+// workerSeed returns the random seed for a worker's query executor and
+// session timings.
+func (e *Executor) workerSeed(id int) uint64 {
+	return e.seed + uint64(id)
+}
+
+// durationMs converts a duration to fractional milliseconds.
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
 func (e *Executor) calculateDelay(activityLevel float64) time.Duration {
 	if activityLevel >= 1.0 {
 		return 0
@@ -377,57 +701,256 @@ func (e *Executor) calculateDelay(activityLevel float64) time.Duration {
 	return baseDelay
 }
 
-func (e *Executor) reporter(ctx context.Context) {
+// reporter logs and records the statistics of each report interval until
+// the context is cancelled, then those of the partial interval since the
+// last report.
+func (e *Executor) reporter(ctx context.Context, timeSeries *report.TimeSeriesWriter) {
 	ticker := time.NewTicker(e.reportInterval)
 	defer ticker.Stop()
 
-	var lastTotal int64
+	var lastTotal, lastFailed, lastTimeouts, lastLagNs, lastReconnects, lastRetries, lastPoolWaits int64
 	lastTime := time.Now()
+	lastLatency := e.latency.snapshot()
+	lastAcquireLatency := e.acquireLatency.snapshot()
+	lastConnectLatency := e.connectLatency.snapshot()
+	lastQueryLatency := make(map[string]HistogramSnapshot)
+
+	// record logs and records the interval since the last report
+	record := func(ctx context.Context, now time.Time) {
+		total := e.totalQueries.Load()
+		success := e.successQueries.Load()
+		failed := e.failedQueries.Load()
+		timeouts := e.timedOutQueries.Load()
+		durationNs := e.totalDurationNs.Load()
+
+		// Calculate rate since last report
+		elapsed := now.Sub(lastTime).Seconds()
+		rate := float64(total-lastTotal) / elapsed
+
+		// Calculate average latency
+		var avgLatencyMs float64
+		if total > 0 {
+			avgLatencyMs = float64(durationNs) / float64(total) / 1e6
+		}
+
+		activityLevel := e.activityLevel(now)
+		profileTime := e.clock.at(now)
+
+		// Latency percentiles cover this interval only
+		latency := e.latency.snapshot()
+		intervalLatency := latency.Sub(lastLatency)
+
+		logEvent := e.log.Info().
+			Int64("total", total).
+			Int64("success", success).
+			Int64("failed", failed).
+			Float64("rate_qps", rate).
+			Float64("avg_latency_ms", avgLatencyMs)
+		logEvent = addLatencyFields(logEvent, intervalLatency).
+			Float64("activity_level", activityLevel)
+		if e.clock.virtual() {
+			logEvent = logEvent.Time("profile_time", profileTime)
+		}
+
+		// Add the phase if the run has a phase schedule
+		phase := e.currentPhase()
+		if phase != "" {
+			logEvent = logEvent.
+				Str("phase", phase).
+				Int64("workers", e.workers.Load())
+		}
+
+		// Add session metrics if workers simulate user sessions
+		if e.sessions {
+			logEvent = logEvent.
+				Int64("total_sessions", e.totalSessions.Load()).
+				Int64("active_sessions", e.activeSessions.Load())
+		}
+
+		// Add timeouts once there have been any
+		if timeouts > 0 {
+			logEvent = logEvent.Int64("timeouts", timeouts)
+		}
+
+		// Add reconnects and retries once there have been any
+		reconnects := e.reconnects.Load()
+		_, retries := e.retryCounts()
+		if reconnects > 0 || retries > 0 {
+			logEvent = logEvent.
+				Int64("reconnects", reconnects).
+				Int64("retries", retries)
+		}
+
+		// Add the workers in an outage if in availability mode
+		if e.availabilityMode {
+			logEvent = logEvent.Int("workers_down", e.downWorkers())
+		}
+
+		// Add the pool usage and acquire latency if there is a pool
+		var poolInUse int32
+		var poolWaits int64
+		var acquireLatency *report.Latency
+		if pool := e.pool.Load(); pool != nil {
+			stat := pool.Stat()
+			poolInUse = stat.AcquiredConns()
+			poolWaits = stat.EmptyAcquireCount()
+			snapshot := e.acquireLatency.snapshot()
+			interval := latencyStats(snapshot.Sub(lastAcquireLatency))
+			acquireLatency = &interval
+			lastAcquireLatency = snapshot
+
+			logEvent = logEvent.
+				Int32("pool_in_use", poolInUse).
+				Int64("pool_waits", poolWaits-lastPoolWaits).
+				Float64("avg_acquire_ms", interval.Mean).
+				Float64("p99_acquire_ms", interval.P99).
+				Float64("max_acquire_ms", interval.Max)
+		}
+
+		// Add the connections opened if in churn mode
+		var connects int64
+		var connectLatency *report.Latency
+		if e.connectionMode == "churn" {
+			snapshot := e.connectLatency.snapshot()
+			interval := snapshot.Sub(lastConnectLatency)
+			connects = interval.Count()
+			stats := latencyStats(interval)
+			connectLatency = &stats
+			lastConnectLatency = snapshot
+
+			logEvent = logEvent.
+				Int64("connects", connects).
+				Int64("connect_errors", e.connectErrors.Load()).
+				Float64("avg_connect_ms", stats.Mean).
+				Float64("p99_connect_ms", stats.P99).
+				Float64("max_connect_ms", stats.Max)
+		}
+
+		// Add schedule metrics if in target rate mode
+		lagNs := e.totalLagNs.Load()
+		if e.targetQPS > 0 {
+			var avgLagMs float64
+			if total > lastTotal {
+				avgLagMs = float64(lagNs-lastLagNs) / float64(total-lastTotal) / 1e6
+			}
+			logEvent = logEvent.
+				Float64("target_qps", e.targetQPS*activityLevel).
+				Float64("avg_schedule_lag_ms", avgLagMs).
+				Float64("max_schedule_lag_ms", float64(e.maxLagNs.Swap(0))/1e6).
+				Int("queue_depth", len(e.arrivals)).
+				Int64("dropped_arrivals", e.droppedArrivals.Load())
+		}
+
+		logEvent.Msg("Statistics")
+
+		// Sample the server's statistics if enabled
+		var server *report.ServerStats
+		if e.serverStats != nil {
+			server = e.serverStats.interval(ctx)
+			if server != nil {
+				addServerStatsFields(e.log.Info(), server).Msg("Server statistics")
+			}
+		}
+
+		// Per-query interval counts, and latency at debug level
+		var queryCounts map[string]int64
+		e.queryMetrics.Range(func(key, value interface{}) bool {
+			name := key.(string)
+			snapshot := value.(*queryMetric).latency.snapshot()
+			interval := snapshot.Sub(lastQueryLatency[name])
+			lastQueryLatency[name] = snapshot
+
+			if interval.Count() > 0 {
+				if queryCounts == nil {
+					queryCounts = make(map[string]int64)
+				}
+				queryCounts[name] = interval.Count()
+				addLatencyFields(e.log.Debug().
+					Str("query", name).
+					Int64("count", interval.Count()), interval).
+					Msg("Query statistics")
+			}
+			return true
+		})
+
+		interval := report.Interval{
+			Time:           now,
+			ProfileTime:    profileTime,
+			ActivityLevel:  activityLevel,
+			Count:          total - lastTotal,
+			Errors:         failed - lastFailed,
+			Timeouts:       timeouts - lastTimeouts,
+			QPS:            rate,
+			ActiveSessions: e.activeSessions.Load(),
+			Workers:        e.workers.Load(),
+			Phase:          phase,
+			Reconnects:     reconnects - lastReconnects,
+			Retries:        retries - lastRetries,
+			Latency:        latencyStats(intervalLatency),
+			PoolInUse:      poolInUse,
+			PoolWaits:      poolWaits - lastPoolWaits,
+			AcquireLatency: acquireLatency,
+			Connects:       connects,
+			ConnectLatency: connectLatency,
+			Queries:        queryCounts,
+			Server:         server,
+		}
+		e.intervalsMu.Lock()
+		e.intervals = append(e.intervals, interval)
+		e.intervalsMu.Unlock()
+
+		// Write the interval to the time series file; stop writing it
+		// if it fails
+		if timeSeries != nil {
+			if err := timeSeries.Write(interval); err != nil {
+				e.log.Warn().Err(err).Msg("Failed to write time series file; no longer writing it")
+				timeSeries = nil
+			}
+		}
+
+		lastLatency = latency
+		lastTotal = total
+		lastFailed = failed
+		lastTimeouts = timeouts
+		lastLagNs = lagNs
+		lastReconnects = reconnects
+		lastRetries = retries
+		lastPoolWaits = poolWaits
+		lastTime = now
+	}
 
 	for {
 		select {
 		case <-ctx.Done():
+			// Record the partial interval since the last report; the workers
+			// have stopped by the time the reporter is stopped
+			if e.totalQueries.Load() > lastTotal {
+				record(context.WithoutCancel(ctx), time.Now())
+			}
 			return
 		case <-ticker.C:
-			now := time.Now()
-			total := e.totalQueries.Load()
-			success := e.successQueries.Load()
-			failed := e.failedQueries.Load()
-			durationNs := e.totalDurationNs.Load()
-
-			// Calculate rate since last report
-			elapsed := now.Sub(lastTime).Seconds()
-			rate := float64(total-lastTotal) / elapsed
-
-			// Calculate average latency
-			var avgLatencyMs float64
-			if total > 0 {
-				avgLatencyMs = float64(durationNs) / float64(total) / 1e6
-			}
-
-			activityLevel := e.profile.GetActivityLevel(now)
-
-			logEvent := logging.Info().
-				Int64("total", total).
-				Int64("success", success).
-				Int64("failed", failed).
-				Float64("rate_qps", rate).
-				Float64("avg_latency_ms", avgLatencyMs).
-				Float64("activity_level", activityLevel)
-
-			// Add session metrics if in session mode
-			if e.connectionMode == "session" {
-				logEvent = logEvent.
-					Int64("total_sessions", e.totalSessions.Load()).
-					Int64("active_sessions", e.activeSessions.Load())
-			}
+			record(ctx, time.Now())
+		}
+	}
+}
 
-			logEvent.Msg("Statistics")
+// queryNames returns the names of the app's queries.
+func (e *Executor) queryNames() []string {
+	var names []string
+	for _, q := range e.app.GetQueries() {
+		names = append(names, q.Name)
+	}
+	return names
+}
 
-			lastTotal = total
-			lastTime = now
-		}
+// maintainsSize reports whether the executor runs size maintenance: it
+// is enabled and the app supports it.
+func (e *Executor) maintainsSize() bool {
+	if !e.maintainSize || e.targetSize <= 0 {
+		return false
 	}
+	_, ok := e.app.(apps.SizeMaintainer)
+	return ok
 }
 
 // cleaner periodically checks if the database has grown beyond the target size
@@ -438,7 +961,7 @@ func (e *Executor) cleaner(ctx context.Context) {
 
 	maintainer := e.app.(apps.SizeMaintainer)
 
-	logging.Info().
+	e.log.Info().
 		Int64("target_size_bytes", e.targetSize).
 		Dur("cleanup_interval", e.cleanupInterval).
 		Msg("Size maintenance enabled")
@@ -449,9 +972,9 @@ func (e *Executor) cleaner(ctx context.Context) {
 			return
 		case <-ticker.C:
 			// Create a dedicated connection for cleanup
-			conn, err := db.ConnectSingle(ctx, e.connString, "cleaner")
+			conn, err := db.ConnectSingle(ctx, e.connString, e.schema, nil, "cleaner")
 			if err != nil {
-				logging.Error().Err(err).Msg("Failed to create cleanup connection")
+				e.log.Error().Err(err).Msg("Failed to create cleanup connection")
 				continue
 			}
 
@@ -459,13 +982,15 @@ func (e *Executor) cleaner(ctx context.Context) {
 			conn.Close(ctx)
 
 			if err != nil {
-				logging.Error().Err(err).Msg("Size maintenance failed")
+				e.log.Error().Err(err).Msg("Size maintenance failed")
 				continue
 			}
 
+			e.lastCleanupNs.Store(time.Now().UnixNano())
+			e.lastDeleted.Store(deleted)
 			if deleted > 0 {
 				e.totalDeleted.Add(deleted)
-				logging.Info().
+				e.log.Info().
 					Int64("deleted", deleted).
 					Int64("total_deleted", e.totalDeleted.Load()).
 					Msg("Size maintenance completed")
@@ -474,9 +999,17 @@ func (e *Executor) cleaner(ctx context.Context) {
 	}
 }
 
+// runEndTime returns the time Run finished, or now if it is still running.
+func (e *Executor) runEndTime() time.Time {
+	if e.endTime.IsZero() {
+		return time.Now()
+	}
+	return e.endTime
+}
+
 // PrintSummary prints a final summary of the workload execution.
 func (e *Executor) PrintSummary() {
-	elapsed := time.Since(e.startTime)
+	elapsed := e.runEndTime().Sub(e.startTime)
 	total := e.totalQueries.Load()
 	success := e.successQueries.Load()
 	failed := e.failedQueries.Load()
@@ -487,7 +1020,7 @@ func (e *Executor) PrintSummary() {
 		avgLatencyMs = float64(durationNs) / float64(total) / 1e6
 	}
 
-	logEvent := logging.Info().
+	logEvent := e.log.Info().
 		Str("connection_mode", e.connectionMode).
 		Dur("duration", elapsed).
 		Int64("total_queries", total).
@@ -495,9 +1028,10 @@ func (e *Executor) PrintSummary() {
 		Int64("failed", failed).
 		Float64("avg_qps", float64(total)/elapsed.Seconds()).
 		Float64("avg_latency_ms", avgLatencyMs)
+	logEvent = addLatencyFields(logEvent, e.latency.snapshot())
 
-	// Add session metrics if in session mode
-	if e.connectionMode == "session" {
+	// Add session metrics if workers simulate user sessions
+	if e.sessions {
 		totalSessions := e.totalSessions.Load()
 		var avgQueriesPerSession float64
 		if totalSessions > 0 {
@@ -508,10 +1042,92 @@ func (e *Executor) PrintSummary() {
 			Float64("avg_queries_per_session", avgQueriesPerSession)
 	}
 
+	// Add schedule metrics if in target rate mode
+	if e.targetQPS > 0 {
+		var avgLagMs float64
+		if total > 0 {
+			avgLagMs = float64(e.totalLagNs.Load()) / float64(total) / 1e6
+		}
+		logEvent = logEvent.
+			Float64("target_qps", e.targetQPS).
+			Float64("avg_schedule_lag_ms", avgLagMs).
+			Int64("dropped_arrivals", e.droppedArrivals.Load())
+	}
+
+	// Add timeouts if there were any
+	if timeouts := e.timedOutQueries.Load(); timeouts > 0 {
+		logEvent = logEvent.Int64("timeouts", timeouts)
+	}
+
+	// Add reconnects and retries if there were any
+	retryCounts, retries := e.retryCounts()
+	if reconnects := e.reconnects.Load(); reconnects > 0 || retries > 0 {
+		logEvent = logEvent.Int64("reconnects", reconnects)
+		for _, class := range retryClasses {
+			logEvent = logEvent.Int64(class+"_retries", retryCounts[class])
+		}
+	}
+
+	// Add the pool totals if there was a pool
+	if pool := e.poolStats(); pool != nil {
+		logEvent = logEvent.
+			Int("pool_size", pool.Size).
+			Int64("pool_acquires", pool.Acquires).
+			Int64("pool_waits", pool.Waits).
+			Float64("avg_acquire_ms", pool.AcquireLatency.Mean).
+			Float64("p99_acquire_ms", pool.AcquireLatency.P99).
+			Float64("max_acquire_ms", pool.AcquireLatency.Max)
+	}
+
+	// Add the connection totals if in churn mode
+	if e.connectionMode == "churn" {
+		connects := e.connectStats()
+		logEvent = logEvent.
+			Int64("connects", connects.Connects).
+			Int64("connect_errors", connects.Errors).
+			Float64("avg_connect_ms", connects.Latency.Mean).
+			Float64("p99_connect_ms", connects.Latency.P99).
+			Float64("max_connect_ms", connects.Latency.Max)
+	}
+
 	logEvent.Msg("Final summary")
 
+	// Warn if load was discarded rather than queued
+	if dropped := e.droppedArrivals.Load(); dropped > 0 {
+		e.log.Warn().
+			Int64("dropped_arrivals", dropped).
+			Msg("Arrivals were dropped because the arrival queue was full; " +
+				"the target rate was not reached")
+	}
+
+	// Print the failures by class and distinct errors if there were any
+	e.logFailures()
+
+	// Print the outages if in availability mode
+	if e.availabilityMode {
+		e.logAvailability()
+	}
+
+	// Print the server statistics if they were sampled
+	if e.serverStats != nil {
+		if server := e.serverStats.runStats(); server != nil {
+			e.logServerStats(server)
+		}
+	}
+
+	// Print per-phase statistics
+	e.intervalsMu.Lock()
+	phaseResults := append([]phaseResult{}, e.phaseResults...)
+	e.intervalsMu.Unlock()
+	if len(phaseResults) > 0 {
+		e.log.Info().Msg("Per-phase statistics:")
+		for _, r := range phaseResults {
+			e.logPhaseResult(r, "")
+		}
+	}
+
 	// Print per-query statistics
-	logging.Info().Msg("Per-query statistics:")
+	e.log.Info().Msg("Per-query statistics:")
 	e.queryMetrics.Range(func(key, value interface{}) bool {
 		name := key.(string)
 		m := value.(*queryMetric)
@@ -524,13 +1140,90 @@ func (e *Executor) PrintSummary() {
 			avgMs = float64(dNs) / float64(count) / 1e6
 		}
 
-		logging.Info().
+		logEvent := e.log.Info().
 			Str("query", name).
 			Int64("count", count).
-			Int64("errors", errors).
-			Float64("avg_latency_ms", avgMs).
+			Int64("errors", errors)
+		if timeouts := m.timeouts.Load(); timeouts > 0 {
+			logEvent = logEvent.Int64("timeouts", timeouts)
+		}
+		logEvent = addFailureFields(logEvent, m.failureCounts())
+		addLatencyFields(logEvent.
+			Float64("avg_latency_ms", avgMs), m.latency.snapshot()).
 			Msg("")
 
 		return true
 	})
 }
+
+// Report returns the results of the workload execution. The caller fills in
+// the run configuration and database metadata.
+func (e *Executor) Report() *report.Report {
+	endTime := e.runEndTime()
+	elapsed := endTime.Sub(e.startTime).Seconds()
+
+	r := &report.Report{
+		Version:         version.Short(),
+		App:             e.app.Name(),
+		Profile:         e.profile.Name(),
+		ConnectionMode:  e.connectionMode,
+		StartTime:       e.startTime,
+		EndTime:         endTime,
+		DurationSeconds: elapsed,
+		Summary: report.QueryStats{
+			Count:    e.totalQueries.Load(),
+			Errors:   e.failedQueries.Load(),
+			Timeouts: e.timedOutQueries.Load(),
+			Failures: e.failureCounts(),
+			Latency:  latencyStats(e.latency.snapshot()),
+		},
+		Queries:      []report.QueryStats{},
+		Reconnects:   e.reconnects.Load(),
+		ErrorSamples: e.errorSampleReport(),
+	}
+	if retryCounts, retries := e.retryCounts(); retries > 0 {
+		r.Retries = retryCounts
+	}
+	if e.availabilityMode {
+		r.Availability = e.availability()
+	}
+	r.Pool = e.poolStats()
+	if e.connectionMode == "churn" {
+		r.Connects = e.connectStats()
+	}
+	if e.serverStats != nil {
+		r.Server = e.serverStats.runStats()
+	}
+	if elapsed > 0 {
+		r.Summary.QPS = float64(r.Summary.Count) / elapsed
+	}
+
+	e.queryMetrics.Range(func(key, value interface{}) bool {
+		m := value.(*queryMetric)
+		q := report.QueryStats{
+			Name:     key.(string),
+			Count:    m.count.Load(),
+			Errors:   m.errors.Load(),
+			Timeouts: m.timeouts.Load(),
+			Failures: m.failureCounts(),
+			Latency:  latencyStats(m.latency.snapshot()),
+		}
+		if elapsed > 0 {
+			q.QPS = float64(q.Count) / elapsed
+		}
+		r.Queries = append(r.Queries, q)
+		return true
+	})
+	sort.Slice(r.Queries, func(i, j int) bool {
+		return r.Queries[i].Name < r.Queries[j].Name
+	})
+
+	e.intervalsMu.Lock()
+	r.Intervals = append([]report.Interval{}, e.intervals...)
+	for _, p := range e.phaseResults {
+		r.Phases = append(r.Phases, p.stats)
+	}
+	e.intervalsMu.Unlock()
+
+	return r
+}
@@ -0,0 +1,193 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package workload
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pgEdge/pgedge-loadgen/internal/apps"
+	_ "github.com/pgEdge/pgedge-loadgen/internal/apps/wholesale"
+)
+
+// newTestExecutor creates an executor for the wholesale app, with the
+// global profile and 4 connections unless set in cfg.
+func newTestExecutor(t *testing.T, cfg ExecutorConfig) *Executor {
+	t.Helper()
+
+	app, err := apps.Get("wholesale")
+	if err != nil {
+		t.Fatalf("Failed to get app: %v", err)
+	}
+	cfg.App = app
+	if cfg.Profile == "" {
+		cfg.Profile = "global"
+	}
+	if cfg.Connections == 0 {
+		cfg.Connections = 4
+	}
+
+	e, err := NewExecutor(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create executor: %v", err)
+	}
+	return e
+}
+
+func TestExecutorReport(t *testing.T) {
+	e := newTestExecutor(t, ExecutorConfig{})
+	e.startTime = time.Now().Add(-10 * time.Second)
+	e.endTime = e.startTime.Add(10 * time.Second)
+
+	for i := 0; i < 50; i++ {
+		e.recordResult(apps.QueryResult{QueryName: "order_status", Duration: int64(time.Millisecond)})
+		e.recordResult(apps.QueryResult{QueryName: "new_order", Duration: int64(10 * time.Millisecond)})
+	}
+	e.recordResult(apps.QueryResult{QueryName: "new_order", Duration: int64(time.Millisecond), Error: errors.New("boom")})
+
+	r := e.Report()
+
+	if r.App != "wholesale" || r.Profile != "global" || r.ConnectionMode != "pool" {
+		t.Errorf("Unexpected report header: app=%s profile=%s mode=%s", r.App, r.Profile, r.ConnectionMode)
+	}
+	if r.DurationSeconds != 10 {
+		t.Errorf("Expected duration 10s, got %v", r.DurationSeconds)
+	}
+	if r.Summary.Count != 101 || r.Summary.Errors != 1 {
+		t.Errorf("Expected 101 queries and 1 error, got %d and %d", r.Summary.Count, r.Summary.Errors)
+	}
+	if r.Summary.QPS != 10.1 {
+		t.Errorf("Expected 10.1 qps, got %v", r.Summary.QPS)
+	}
+
+	if len(r.Queries) != 2 {
+		t.Fatalf("Expected 2 query types, got %d", len(r.Queries))
+	}
+	if r.Queries[0].Name != "new_order" || r.Queries[1].Name != "order_status" {
+		t.Errorf("Expected queries sorted by name, got %s, %s", r.Queries[0].Name, r.Queries[1].Name)
+	}
+	if p50 := r.Queries[0].Latency.P50; p50 < 9.9 || p50 > 10.1 {
+		t.Errorf("Expected new_order p50 ~10ms, got %v", p50)
+	}
+}
+
+func TestExecutorSeed(t *testing.T) {
+	e := newTestExecutor(t, ExecutorConfig{Seed: 42})
+	if e.seed != 42 {
+		t.Errorf("Expected seed 42, got %d", e.seed)
+	}
+	if e.workerSeed(3) != 45 {
+		t.Errorf("Expected worker seed 45, got %d", e.workerSeed(3))
+	}
+
+	// Unseeded executors pick a seed
+	if e := newTestExecutor(t, ExecutorConfig{}); e.seed == 0 {
+		t.Error("Expected a random seed, got 0")
+	}
+}
+
+func TestRandomDuration(t *testing.T) {
+	r1 := rand.New(rand.NewPCG(42, 0))
+	r2 := rand.New(rand.NewPCG(42, 0))
+
+	for i := 0; i < 100; i++ {
+		d1 := randomDuration(r1, time.Second, 5*time.Second)
+		d2 := randomDuration(r2, time.Second, 5*time.Second)
+		if d1 != d2 {
+			t.Fatalf("Same seed produced different durations: %v != %v", d1, d2)
+		}
+		if d1 < time.Second || d1 >= 5*time.Second {
+			t.Fatalf("Duration %v out of range", d1)
+		}
+	}
+
+	if d := randomDuration(r1, time.Second, time.Second); d != time.Second {
+		t.Errorf("Expected min for empty range, got %v", d)
+	}
+}
+
+func TestReporterFinalInterval(t *testing.T) {
+	e := newTestExecutor(t, ExecutorConfig{})
+	e.reportInterval = time.Hour
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Without queries since the last report, no interval is recorded
+	e.reporter(ctx, nil)
+	if n := len(e.Report().Intervals); n != 0 {
+		t.Fatalf("Expected no intervals, got %d", n)
+	}
+
+	// The queries since the last report are recorded when the reporter
+	// stops
+	for i := 0; i < 5; i++ {
+		e.recordResult(apps.QueryResult{QueryName: "new_order", Duration: int64(time.Millisecond)})
+	}
+	e.reporter(ctx, nil)
+	intervals := e.Report().Intervals
+	if len(intervals) != 1 || intervals[0].Count != 5 || intervals[0].Queries["new_order"] != 5 {
+		t.Errorf("Expected a final interval with 5 queries, got %+v", intervals)
+	}
+}
+
+func TestExecutorTimeSeries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "timeseries.csv")
+	e := newTestExecutor(t, ExecutorConfig{
+		ConnString:     "host=/nonexistent",
+		TimeSeriesFile: path,
+		Phases:         []Phase{{Duration: 200 * time.Millisecond, Connections: 1}},
+	})
+	e.reportInterval = 50 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := e.Run(ctx); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	// The file has a row for each interval, with a count column for each
+	// of the app's queries
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open time series file: %v", err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse time series file: %v", err)
+	}
+	if rows, intervals := len(records)-1, len(e.Report().Intervals); rows == 0 || rows != intervals {
+		t.Errorf("Expected a row for each of %d intervals, got %d", intervals, rows)
+	}
+	if header := records[0]; header[len(header)-len(e.app.GetQueries())] != e.app.GetQueries()[0].Name+"_count" {
+		t.Errorf("Expected query count columns, got %v", header)
+	}
+}
+
+func TestExecutorTimeSeriesCreateFails(t *testing.T) {
+	e := newTestExecutor(t, ExecutorConfig{
+		ConnString:     "host=/nonexistent",
+		TimeSeriesFile: filepath.Join(t.TempDir(), "missing", "timeseries.csv"),
+		PoolSize:       2,
+	})
+
+	// The run fails before connecting, rather than with a connection error
+	err := e.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "time series file") {
+		t.Errorf("Expected time series file error, got %v", err)
+	}
+}
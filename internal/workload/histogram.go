@@ -0,0 +1,187 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package workload
+
+import (
+	"math"
+	"math/bits"
+	"sync/atomic"
+	"time"
+)
+
+// Histogram layout. Values are recorded in microseconds using a log-linear
+// bucketing scheme similar to HDR Histogram: values below 128us are stored
+// exactly, and larger values are stored in buckets whose width is at most
+// 1/64th of their lower bound, giving a relative error below 1%.
+const (
+	histSubBucketBits = 7
+	histSubBuckets    = 1 << histSubBucketBits
+	histHalfBuckets   = histSubBuckets / 2
+	histMaxShift      = 34 // values up to 2^41us (~25 days)
+	histBucketCount   = (histMaxShift + 2) * histHalfBuckets
+)
+
+// Percentiles reported for latency histograms.
+var reportedPercentiles = []struct {
+	name  string
+	value float64
+}{
+	{"p50", 50},
+	{"p90", 90},
+	{"p95", 95},
+	{"p99", 99},
+	{"p999", 99.9},
+}
+
+// histogram is a lock-free latency histogram safe for concurrent use.
+type histogram struct {
+	counts [histBucketCount]atomic.Int64
+	total  atomic.Int64
+	sumNs  atomic.Int64
+	maxNs  atomic.Int64
+}
+
+func newHistogram() *histogram {
+	return &histogram{}
+}
+
+// record adds a duration in nanoseconds to the histogram.
+func (h *histogram) record(ns int64) {
+	if ns < 0 {
+		ns = 0
+	}
+	h.counts[histBucketIndex(ns/1000)].Add(1)
+	h.total.Add(1)
+	h.sumNs.Add(ns)
+	for {
+		current := h.maxNs.Load()
+		if ns <= current || h.maxNs.CompareAndSwap(current, ns) {
+			return
+		}
+	}
+}
+
+// snapshot returns a point-in-time copy of the histogram.
+func (h *histogram) snapshot() HistogramSnapshot {
+	s := HistogramSnapshot{
+		counts: make([]int64, histBucketCount),
+		total:  h.total.Load(),
+		sumNs:  h.sumNs.Load(),
+		maxNs:  h.maxNs.Load(),
+	}
+	for i := range h.counts {
+		s.counts[i] = h.counts[i].Load()
+	}
+	return s
+}
+
+// HistogramSnapshot is an immutable copy of a latency histogram.
+type HistogramSnapshot struct {
+	counts []int64
+	total  int64
+	sumNs  int64
+	maxNs  int64
+}
+
+// Count returns the number of recorded values.
+func (s HistogramSnapshot) Count() int64 {
+	return s.total
+}
+
+// Mean returns the mean recorded value.
+func (s HistogramSnapshot) Mean() time.Duration {
+	if s.total == 0 {
+		return 0
+	}
+	return time.Duration(s.sumNs / s.total)
+}
+
+// Max returns the largest recorded value.
+func (s HistogramSnapshot) Max() time.Duration {
+	return time.Duration(s.maxNs)
+}
+
+// Percentile returns the value at or below which the given percentage
+// (0-100) of recorded values fall.
+func (s HistogramSnapshot) Percentile(p float64) time.Duration {
+	if s.total == 0 {
+		return 0
+	}
+
+	rank := int64(math.Ceil(p / 100 * float64(s.total)))
+	rank = min(max(rank, 1), s.total)
+
+	var cumulative int64
+	for i, c := range s.counts {
+		cumulative += c
+		if cumulative >= rank {
+			lower, upper := histBucketRange(i)
+			value := time.Duration((lower+upper)/2) * time.Microsecond
+			return min(value, s.Max())
+		}
+	}
+	return s.Max()
+}
+
+// countAtOrBelow returns the number of recorded values less than or equal
+// to d, to the resolution of the histogram buckets.
+func (s HistogramSnapshot) countAtOrBelow(d time.Duration) int64 {
+	limit := int64(d / time.Microsecond)
+
+	var count int64
+	for i, c := range s.counts {
+		if _, upper := histBucketRange(i); upper > limit {
+			break
+		}
+		count += c
+	}
+	return count
+}
+
+// Sub returns the values recorded since prev was taken. The maximum of the
+// result is approximated by the upper bound of the highest non-empty bucket.
+func (s HistogramSnapshot) Sub(prev HistogramSnapshot) HistogramSnapshot {
+	d := HistogramSnapshot{
+		counts: make([]int64, len(s.counts)),
+		total:  s.total - prev.total,
+		sumNs:  s.sumNs - prev.sumNs,
+	}
+	for i := range s.counts {
+		d.counts[i] = s.counts[i]
+		if i < len(prev.counts) {
+			d.counts[i] -= prev.counts[i]
+		}
+		if d.counts[i] > 0 {
+			_, upper := histBucketRange(i)
+			d.maxNs = min((upper+1)*1000, s.maxNs)
+		}
+	}
+	return d
+}
+
+// histBucketIndex returns the bucket index for a value in microseconds.
+func histBucketIndex(us int64) int {
+	shift := max(bits.Len64(uint64(us))-histSubBucketBits, 0)
+	if shift > histMaxShift {
+		return histBucketCount - 1
+	}
+	return shift*histHalfBuckets + int(us>>shift)
+}
+
+// histBucketRange returns the inclusive range of values in microseconds
+// stored in the given bucket.
+func histBucketRange(index int) (int64, int64) {
+	if index < histSubBuckets {
+		return int64(index), int64(index)
+	}
+	shift := index/histHalfBuckets - 1
+	mantissa := int64(index%histHalfBuckets + histHalfBuckets)
+	return mantissa << shift, (mantissa+1)<<shift - 1
+}
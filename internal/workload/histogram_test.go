@@ -0,0 +1,139 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package workload
+
+import (
+	"math"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHistBucketRoundTrip(t *testing.T) {
+	values := []int64{0, 1, 64, 127, 128, 129, 255, 256, 1000, 12345, 999999, 1 << 30, 1 << 40}
+
+	for _, v := range values {
+		idx := histBucketIndex(v)
+		if idx < 0 || idx >= histBucketCount {
+			t.Fatalf("histBucketIndex(%d) = %d out of range", v, idx)
+		}
+		lower, upper := histBucketRange(idx)
+		if v < lower || v > upper {
+			t.Errorf("value %d not within bucket %d range [%d, %d]", v, idx, lower, upper)
+		}
+		if v >= histSubBuckets {
+			relErr := float64(upper-lower) / float64(lower)
+			if relErr > 1.0/64 {
+				t.Errorf("bucket %d for value %d too wide: relative width %f", idx, v, relErr)
+			}
+		}
+	}
+}
+
+func TestHistBucketsContiguous(t *testing.T) {
+	_, prevUpper := histBucketRange(0)
+	for i := 1; i < histBucketCount; i++ {
+		lower, upper := histBucketRange(i)
+		if lower != prevUpper+1 {
+			t.Fatalf("bucket %d lower bound %d does not follow previous upper bound %d", i, lower, prevUpper)
+		}
+		prevUpper = upper
+	}
+}
+
+func TestHistogramPercentiles(t *testing.T) {
+	h := newHistogram()
+
+	// Record 1ms..1000ms
+	for i := 1; i <= 1000; i++ {
+		h.record(int64(i) * int64(time.Millisecond))
+	}
+
+	s := h.snapshot()
+	if s.Count() != 1000 {
+		t.Fatalf("Expected count 1000, got %d", s.Count())
+	}
+	if s.Max() != 1000*time.Millisecond {
+		t.Errorf("Expected max 1000ms, got %v", s.Max())
+	}
+	if mean := s.Mean(); mean < 500*time.Millisecond || mean > 501*time.Millisecond {
+		t.Errorf("Expected mean ~500.5ms, got %v", mean)
+	}
+
+	tests := []struct {
+		percentile float64
+		expected   time.Duration
+	}{
+		{50, 500 * time.Millisecond},
+		{90, 900 * time.Millisecond},
+		{99, 990 * time.Millisecond},
+		{99.9, 999 * time.Millisecond},
+		{100, 1000 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		got := s.Percentile(tt.percentile)
+		relErr := math.Abs(float64(got-tt.expected)) / float64(tt.expected)
+		if relErr > 0.01 {
+			t.Errorf("Percentile(%v) = %v, expected ~%v", tt.percentile, got, tt.expected)
+		}
+	}
+}
+
+func TestHistogramEmpty(t *testing.T) {
+	s := newHistogram().snapshot()
+
+	if s.Count() != 0 || s.Mean() != 0 || s.Max() != 0 || s.Percentile(99) != 0 {
+		t.Error("Expected zero values for empty histogram")
+	}
+}
+
+func TestHistogramSub(t *testing.T) {
+	h := newHistogram()
+	for i := 0; i < 100; i++ {
+		h.record(int64(time.Millisecond))
+	}
+	before := h.snapshot()
+
+	for i := 0; i < 10; i++ {
+		h.record(int64(50 * time.Millisecond))
+	}
+	interval := h.snapshot().Sub(before)
+
+	if interval.Count() != 10 {
+		t.Fatalf("Expected interval count 10, got %d", interval.Count())
+	}
+	if p50 := interval.Percentile(50); p50 < 49*time.Millisecond || p50 > 51*time.Millisecond {
+		t.Errorf("Expected interval p50 ~50ms, got %v", p50)
+	}
+	if interval.Max() < 50*time.Millisecond || interval.Max() > 51*time.Millisecond {
+		t.Errorf("Expected interval max ~50ms, got %v", interval.Max())
+	}
+}
+
+func TestHistogramConcurrent(t *testing.T) {
+	h := newHistogram()
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				h.record(int64(i) * int64(time.Microsecond))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if count := h.snapshot().Count(); count != 8000 {
+		t.Errorf("Expected count 8000, got %d", count)
+	}
+}
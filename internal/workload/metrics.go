@@ -0,0 +1,293 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package workload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/pgEdge/pgedge-loadgen/internal/logging"
+)
+
+const metricsNamespace = "pgedge_loadgen"
+
+// metricsBuckets are the upper bounds in seconds of the latency histogram
+// buckets exposed to Prometheus.
+var metricsBuckets = []float64{
+	0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05,
+	0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60,
+}
+
+// metricsCollector exposes the executor metrics to Prometheus. Values are
+// read from the executor at scrape time rather than being recorded twice.
+type metricsCollector struct {
+	e *Executor
+
+	queries        *prometheus.Desc
+	queryErrors    *prometheus.Desc
+	queryTimeouts  *prometheus.Desc
+	queryFailures  *prometheus.Desc
+	queryDuration  *prometheus.Desc
+	connections    *prometheus.Desc
+	phase          *prometheus.Desc
+	activityLevel  *prometheus.Desc
+	totalSessions  *prometheus.Desc
+	activeSessions *prometheus.Desc
+	deletedRows    *prometheus.Desc
+	targetQPS      *prometheus.Desc
+	queueDepth     *prometheus.Desc
+	dropped        *prometheus.Desc
+	reconnects     *prometheus.Desc
+	retries        *prometheus.Desc
+	workersDown    *prometheus.Desc
+	poolConns      *prometheus.Desc
+	poolWaits      *prometheus.Desc
+	poolAcquire    *prometheus.Desc
+	connects       *prometheus.Desc
+	connectErrors  *prometheus.Desc
+	connectLatency *prometheus.Desc
+}
+
+func newMetricsCollector(e *Executor) *metricsCollector {
+	labels := prometheus.Labels{"app": e.app.Name()}
+	if e.schema != "" {
+		labels["schema"] = e.schema
+	}
+	desc := func(name, help string, variableLabels ...string) *prometheus.Desc {
+		return prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", name),
+			help, variableLabels, labels)
+	}
+
+	return &metricsCollector{
+		e: e,
+		queries: desc("queries_total",
+			"Total number of queries executed.", "query"),
+		queryErrors: desc("query_errors_total",
+			"Total number of failed queries.", "query"),
+		queryTimeouts: desc("query_timeouts_total",
+			"Total number of queries that timed out.", "query"),
+		queryFailures: desc("query_failures_total",
+			"Total number of failed and timed-out queries by failure class.", "query", "class"),
+		queryDuration: desc("query_duration_seconds",
+			"Query latency in seconds.", "query"),
+		connections: desc("connections",
+			"Number of running worker connections."),
+		phase: desc("phase",
+			"Number (from 1) of the running load phase (phase schedule only).", "phase"),
+		activityLevel: desc("activity_level",
+			"Current activity level of the usage profile.", "profile"),
+		totalSessions: desc("sessions_total",
+			"Total number of user sessions started (session mode only)."),
+		activeSessions: desc("active_sessions",
+			"Number of user sessions currently active (session mode only)."),
+		deletedRows: desc("cleanup_deleted_rows_total",
+			"Total number of rows deleted by size maintenance."),
+		targetQPS: desc("target_qps",
+			"Current target query rate (target rate mode only)."),
+		queueDepth: desc("arrival_queue_depth",
+			"Number of scheduled arrivals waiting for a worker (target rate mode only)."),
+		dropped: desc("dropped_arrivals_total",
+			"Total number of arrivals dropped because the queue was full (target rate mode only)."),
+		reconnects: desc("reconnects_total",
+			"Total number of lost worker connections that were re-established."),
+		retries: desc("retries_total",
+			"Total number of retried queries.", "class"),
+		workersDown: desc("workers_down",
+			"Number of workers whose queries are failing because of an outage (availability mode only)."),
+		poolConns: desc("pool_connections",
+			"Number of connections in the shared pool (pool size set only).", "state"),
+		poolWaits: desc("pool_acquire_waits_total",
+			"Total number of pool acquires that waited for a connection (pool size set only)."),
+		poolAcquire: desc("pool_acquire_duration_seconds",
+			"Time waiting to acquire a pooled connection in seconds (pool size set only)."),
+		connects: desc("connects_total",
+			"Total number of worker connections opened."),
+		connectErrors: desc("connect_errors_total",
+			"Total number of failed worker connection attempts."),
+		connectLatency: desc("connect_duration_seconds",
+			"Time to establish and authenticate a worker connection in seconds."),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *metricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.queries
+	ch <- c.queryErrors
+	ch <- c.queryTimeouts
+	ch <- c.queryFailures
+	ch <- c.queryDuration
+	ch <- c.connections
+	ch <- c.phase
+	ch <- c.activityLevel
+	ch <- c.totalSessions
+	ch <- c.activeSessions
+	ch <- c.deletedRows
+	ch <- c.targetQPS
+	ch <- c.queueDepth
+	ch <- c.dropped
+	ch <- c.reconnects
+	ch <- c.retries
+	ch <- c.workersDown
+	ch <- c.poolConns
+	ch <- c.poolWaits
+	ch <- c.poolAcquire
+	ch <- c.connects
+	ch <- c.connectErrors
+	ch <- c.connectLatency
+}
+
+// Collect implements prometheus.Collector.
+func (c *metricsCollector) Collect(ch chan<- prometheus.Metric) {
+	e := c.e
+
+	e.queryMetrics.Range(func(key, value interface{}) bool {
+		name := key.(string)
+		m := value.(*queryMetric)
+
+		ch <- prometheus.MustNewConstMetric(c.queries,
+			prometheus.CounterValue, float64(m.count.Load()), name)
+		ch <- prometheus.MustNewConstMetric(c.queryErrors,
+			prometheus.CounterValue, float64(m.errors.Load()), name)
+		ch <- prometheus.MustNewConstMetric(c.queryTimeouts,
+			prometheus.CounterValue, float64(m.timeouts.Load()), name)
+		for _, class := range failureClasses {
+			ch <- prometheus.MustNewConstMetric(c.queryFailures,
+				prometheus.CounterValue, float64(m.failures[class].Load()), name, class)
+		}
+
+		ch <- constHistogram(c.queryDuration, m.latency.snapshot(), name)
+		return true
+	})
+
+	activityLevel := e.activityLevel(time.Now())
+	ch <- prometheus.MustNewConstMetric(c.connections,
+		prometheus.GaugeValue, float64(e.workers.Load()))
+	ch <- prometheus.MustNewConstMetric(c.activityLevel,
+		prometheus.GaugeValue, activityLevel, e.profile.Name())
+	ch <- prometheus.MustNewConstMetric(c.deletedRows,
+		prometheus.CounterValue, float64(e.totalDeleted.Load()))
+	ch <- prometheus.MustNewConstMetric(c.reconnects,
+		prometheus.CounterValue, float64(e.reconnects.Load()))
+	for _, class := range retryClasses {
+		ch <- prometheus.MustNewConstMetric(c.retries,
+			prometheus.CounterValue, float64(e.retries[class].Load()), class)
+	}
+	connectLatency := e.connectLatency.snapshot()
+	ch <- prometheus.MustNewConstMetric(c.connects,
+		prometheus.CounterValue, float64(connectLatency.Count()))
+	ch <- prometheus.MustNewConstMetric(c.connectErrors,
+		prometheus.CounterValue, float64(e.connectErrors.Load()))
+	ch <- constHistogram(c.connectLatency, connectLatency)
+
+	if len(e.phases) > 0 {
+		ch <- prometheus.MustNewConstMetric(c.phase,
+			prometheus.GaugeValue, float64(e.phase.Load()+1), e.currentPhase())
+	}
+
+	if e.availabilityMode {
+		ch <- prometheus.MustNewConstMetric(c.workersDown,
+			prometheus.GaugeValue, float64(e.downWorkers()))
+	}
+
+	if pool := e.pool.Load(); pool != nil {
+		stat := pool.Stat()
+		ch <- prometheus.MustNewConstMetric(c.poolConns,
+			prometheus.GaugeValue, float64(stat.AcquiredConns()), "in_use")
+		ch <- prometheus.MustNewConstMetric(c.poolConns,
+			prometheus.GaugeValue, float64(stat.IdleConns()), "idle")
+		ch <- prometheus.MustNewConstMetric(c.poolWaits,
+			prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+		ch <- constHistogram(c.poolAcquire, e.acquireLatency.snapshot())
+	}
+
+	if e.sessions {
+		ch <- prometheus.MustNewConstMetric(c.totalSessions,
+			prometheus.CounterValue, float64(e.totalSessions.Load()))
+		ch <- prometheus.MustNewConstMetric(c.activeSessions,
+			prometheus.GaugeValue, float64(e.activeSessions.Load()))
+	}
+
+	if e.targetQPS > 0 {
+		ch <- prometheus.MustNewConstMetric(c.targetQPS,
+			prometheus.GaugeValue, e.targetQPS*activityLevel)
+		ch <- prometheus.MustNewConstMetric(c.queueDepth,
+			prometheus.GaugeValue, float64(len(e.arrivals)))
+		ch <- prometheus.MustNewConstMetric(c.dropped,
+			prometheus.CounterValue, float64(e.droppedArrivals.Load()))
+	}
+}
+
+// constHistogram converts a latency histogram snapshot to a Prometheus
+// histogram with the metricsBuckets buckets.
+func constHistogram(desc *prometheus.Desc, s HistogramSnapshot, labelValues ...string) prometheus.Metric {
+	buckets := make(map[float64]uint64, len(metricsBuckets))
+	for _, b := range metricsBuckets {
+		buckets[b] = uint64(s.countAtOrBelow(time.Duration(b * float64(time.Second))))
+	}
+	return prometheus.MustNewConstHistogram(desc,
+		uint64(s.Count()), time.Duration(s.sumNs).Seconds(), buckets, labelValues...)
+}
+
+// ServeMetrics starts an HTTP server exposing Prometheus metrics for the
+// executors on /metrics at the given address. The listener is opened before
+// returning so that address errors are reported immediately; the server is
+// shut down when the context is cancelled.
+func ServeMetrics(ctx context.Context, addr string, executors ...*Executor) error {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	for _, e := range executors {
+		if err := registry.Register(newMetricsCollector(e)); err != nil {
+			return fmt.Errorf("failed to register metrics: %w", err)
+		}
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logging.Error().Err(err).Msg("Metrics server failed")
+		}
+	}()
+
+	logging.Info().
+		Str("address", listener.Addr().String()).
+		Msg("Serving Prometheus metrics on /metrics")
+
+	return nil
+}
@@ -0,0 +1,196 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package workload
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/pgEdge/pgedge-loadgen/internal/apps"
+)
+
+func gatherMetrics(t *testing.T, e *Executor) map[string]*dto.MetricFamily {
+	t.Helper()
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(newMetricsCollector(e))
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+
+	byName := make(map[string]*dto.MetricFamily)
+	for _, f := range families {
+		byName[f.GetName()] = f
+	}
+	return byName
+}
+
+func TestMetricsCollector(t *testing.T) {
+	e := newTestExecutor(t, ExecutorConfig{})
+
+	for i := 0; i < 9; i++ {
+		e.recordResult(apps.QueryResult{QueryName: "new_order", Duration: int64(2 * time.Millisecond)})
+	}
+	e.recordResult(apps.QueryResult{QueryName: "new_order", Duration: int64(2 * time.Second), Error: errors.New("boom")})
+	e.totalDeleted.Add(42)
+	e.reconnects.Add(3)
+	e.retries[classDeadlock].Add(2)
+
+	families := gatherMetrics(t, e)
+
+	queries := families["pgedge_loadgen_queries_total"]
+	if queries == nil || len(queries.GetMetric()) != 1 {
+		t.Fatalf("Expected one queries_total series, got %v", queries)
+	}
+	if v := queries.GetMetric()[0].GetCounter().GetValue(); v != 10 {
+		t.Errorf("Expected 10 queries, got %v", v)
+	}
+
+	labels := make(map[string]string)
+	for _, l := range queries.GetMetric()[0].GetLabel() {
+		labels[l.GetName()] = l.GetValue()
+	}
+	if labels["app"] != "wholesale" || labels["query"] != "new_order" {
+		t.Errorf("Unexpected labels: %v", labels)
+	}
+
+	if v := families["pgedge_loadgen_query_errors_total"].GetMetric()[0].GetCounter().GetValue(); v != 1 {
+		t.Errorf("Expected 1 error, got %v", v)
+	}
+
+	hist := families["pgedge_loadgen_query_duration_seconds"].GetMetric()[0].GetHistogram()
+	if hist.GetSampleCount() != 10 {
+		t.Errorf("Expected histogram count 10, got %d", hist.GetSampleCount())
+	}
+	for _, b := range hist.GetBucket() {
+		var expected uint64
+		switch {
+		case b.GetUpperBound() >= 2.5:
+			expected = 10
+		case b.GetUpperBound() >= 0.0025:
+			expected = 9
+		}
+		if b.GetCumulativeCount() != expected {
+			t.Errorf("Bucket le=%v: expected %d, got %d", b.GetUpperBound(), expected, b.GetCumulativeCount())
+		}
+	}
+
+	if v := families["pgedge_loadgen_cleanup_deleted_rows_total"].GetMetric()[0].GetCounter().GetValue(); v != 42 {
+		t.Errorf("Expected 42 deleted rows, got %v", v)
+	}
+	if families["pgedge_loadgen_activity_level"] == nil {
+		t.Error("Expected activity_level metric")
+	}
+	if families["pgedge_loadgen_connects_total"] == nil ||
+		families["pgedge_loadgen_connect_duration_seconds"] == nil {
+		t.Error("Expected connect metrics")
+	}
+	if v := families["pgedge_loadgen_reconnects_total"].GetMetric()[0].GetCounter().GetValue(); v != 3 {
+		t.Errorf("Expected 3 reconnects, got %v", v)
+	}
+	retries := families["pgedge_loadgen_retries_total"]
+	if retries == nil || len(retries.GetMetric()) != len(retryClasses) {
+		t.Fatalf("Expected a retries_total series per class, got %v", retries)
+	}
+	for _, m := range retries.GetMetric() {
+		var class string
+		for _, l := range m.GetLabel() {
+			if l.GetName() == "class" {
+				class = l.GetValue()
+			}
+		}
+		if want := e.retries[class].Load(); m.GetCounter().GetValue() != float64(want) {
+			t.Errorf("Expected %d %s retries, got %v", want, class, m.GetCounter().GetValue())
+		}
+	}
+
+	// Mode-specific metrics are only exposed in their mode
+	if families["pgedge_loadgen_active_sessions"] != nil {
+		t.Error("Unexpected active_sessions metric in pool mode")
+	}
+	if families["pgedge_loadgen_dropped_arrivals_total"] != nil {
+		t.Error("Unexpected dropped_arrivals_total metric in closed loop mode")
+	}
+}
+
+func TestMetricsCollectorModes(t *testing.T) {
+	e := newTestExecutor(t, ExecutorConfig{ConnectionMode: "session", Sessions: true})
+	if gatherMetrics(t, e)["pgedge_loadgen_active_sessions"] == nil {
+		t.Error("Expected active_sessions metric in session mode")
+	}
+
+	e = newTestExecutor(t, ExecutorConfig{ConnectionMode: "churn", Sessions: true})
+	if gatherMetrics(t, e)["pgedge_loadgen_active_sessions"] == nil {
+		t.Error("Expected active_sessions metric in churn mode with sessions")
+	}
+
+	e = newTestExecutor(t, ExecutorConfig{TargetQPS: 100})
+	families := gatherMetrics(t, e)
+	if families["pgedge_loadgen_dropped_arrivals_total"] == nil ||
+		families["pgedge_loadgen_arrival_queue_depth"] == nil {
+		t.Error("Expected schedule metrics in target rate mode")
+	}
+
+	e = newTestExecutor(t, ExecutorConfig{PoolSize: 2})
+	if gatherMetrics(t, e)["pgedge_loadgen_pool_connections"] != nil {
+		t.Error("Unexpected pool_connections metric before the pool is created")
+	}
+	newTestPool(t, e)
+	e.acquireLatency.record(int64(time.Millisecond))
+	families = gatherMetrics(t, e)
+	if conns := families["pgedge_loadgen_pool_connections"]; conns == nil || len(conns.GetMetric()) != 2 {
+		t.Errorf("Expected in_use and idle pool_connections series, got %v", conns)
+	}
+	if hist := families["pgedge_loadgen_pool_acquire_duration_seconds"]; hist == nil ||
+		hist.GetMetric()[0].GetHistogram().GetSampleCount() != 1 {
+		t.Errorf("Expected pool_acquire_duration_seconds with 1 sample, got %v", hist)
+	}
+	if families["pgedge_loadgen_pool_acquire_waits_total"] == nil {
+		t.Error("Expected pool_acquire_waits_total metric")
+	}
+}
+
+func TestMetricsCollectorSchemas(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	for _, schema := range []string{"east", "west"} {
+		e := newTestExecutor(t, ExecutorConfig{Schema: schema})
+		e.recordResult(apps.QueryResult{QueryName: "new_order", Duration: int64(time.Millisecond)})
+		if err := registry.Register(newMetricsCollector(e)); err != nil {
+			t.Fatalf("Failed to register collector for schema '%s': %v", schema, err)
+		}
+	}
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+
+	schemas := make(map[string]bool)
+	for _, f := range families {
+		if f.GetName() != "pgedge_loadgen_queries_total" {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "schema" {
+					schemas[l.GetValue()] = true
+				}
+			}
+		}
+	}
+	if !schemas["east"] || !schemas["west"] {
+		t.Errorf("Expected queries_total series for both schemas, got %v", schemas)
+	}
+}
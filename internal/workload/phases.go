@@ -0,0 +1,220 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package workload
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pgEdge/pgedge-loadgen/internal/report"
+)
+
+// phaseTick is how often the number of workers is adjusted during a phase
+// schedule.
+const phaseTick = 100 * time.Millisecond
+
+// Phase is a stage of a run's load schedule.
+type Phase struct {
+	Name        string
+	Duration    time.Duration
+	Connections int  // Number of workers at the end of the phase
+	Ramp        bool // Change the worker count linearly over the phase
+}
+
+// phaseAt returns the index of the phase at the given time since the start
+// of the schedule, and the number of workers that should then be running.
+// The index is len(phases) once the schedule has ended.
+func phaseAt(phases []Phase, elapsed time.Duration) (int, int) {
+	from := 0
+	for i, p := range phases {
+		if elapsed < p.Duration {
+			if !p.Ramp {
+				return i, p.Connections
+			}
+			frac := float64(elapsed) / float64(p.Duration)
+			return i, from + int(math.Round(frac*float64(p.Connections-from)))
+		}
+		elapsed -= p.Duration
+		from = p.Connections
+	}
+	return len(phases), from
+}
+
+// phaseName returns the name of the phase with the given index.
+func (e *Executor) phaseName(index int) string {
+	if name := e.phases[index].Name; name != "" {
+		return name
+	}
+	return fmt.Sprintf("phase %d", index+1)
+}
+
+// currentPhase returns the name of the running phase, or "" if the run has
+// no phase schedule.
+func (e *Executor) currentPhase() string {
+	if len(e.phases) == 0 {
+		return ""
+	}
+	return e.phaseName(int(e.phase.Load()))
+}
+
+// workerSet starts and retires workers. Workers are retired in the reverse
+// order to which they were started. Each worker started gets a new ID, and
+// hence its own seed and application name, so that a worker started after
+// a ramp-down does not replay the parameters of a retired one.
+type workerSet struct {
+	e      *Executor
+	ctx    context.Context
+	wg     sync.WaitGroup
+	stops  []chan struct{} // stop channels of the running workers
+	nextID int
+}
+
+// resize starts or retires workers until n are running. Retired workers
+// finish their current query (or session) before stopping.
+func (s *workerSet) resize(n int) {
+	for len(s.stops) < n {
+		id := s.nextID
+		s.nextID++
+		stop := make(chan struct{})
+		s.stops = append(s.stops, stop)
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.e.worker(s.ctx, id, stop)
+		}()
+	}
+	for len(s.stops) > n {
+		last := len(s.stops) - 1
+		close(s.stops[last])
+		s.stops = s.stops[:last]
+	}
+	s.e.workers.Store(int64(n))
+}
+
+// wait blocks until all workers, including retired ones, have stopped.
+func (s *workerSet) wait() {
+	s.wg.Wait()
+}
+
+// phaseMark records the counters at the start of a phase.
+type phaseMark struct {
+	time     time.Time
+	total    int64
+	failed   int64
+	timeouts int64
+	latency  HistogramSnapshot
+}
+
+// runPhases steps through the phase schedule, adjusting the number of
+// workers, until the last phase ends or the context is cancelled.
+func (e *Executor) runPhases(ctx context.Context, workers *workerSet) {
+	ticker := time.NewTicker(phaseTick)
+	defer ticker.Stop()
+
+	start := time.Now()
+	current := -1
+	var mark phaseMark
+
+	for {
+		now := time.Now()
+		index, n := phaseAt(e.phases, now.Sub(start))
+
+		if index != current {
+			if current >= 0 {
+				e.endPhase(current, mark, now)
+			}
+			if index == len(e.phases) {
+				e.log.Info().Msg("Phase schedule complete")
+				return
+			}
+
+			current = index
+			e.phase.Store(int32(index))
+			mark = phaseMark{
+				time:     now,
+				total:    e.totalQueries.Load(),
+				failed:   e.failedQueries.Load(),
+				timeouts: e.timedOutQueries.Load(),
+				latency:  e.latency.snapshot(),
+			}
+
+			p := e.phases[index]
+			e.log.Info().
+				Str("phase", e.phaseName(index)).
+				Int("connections", p.Connections).
+				Bool("ramp", p.Ramp).
+				Dur("duration", p.Duration).
+				Msg("Starting phase")
+		}
+
+		workers.resize(n)
+
+		select {
+		case <-ctx.Done():
+			e.endPhase(current, mark, time.Now())
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// phaseResult holds the statistics of a finished phase.
+type phaseResult struct {
+	stats   report.PhaseStats
+	latency HistogramSnapshot
+}
+
+// endPhase logs and records the statistics of a finished phase.
+func (e *Executor) endPhase(index int, mark phaseMark, now time.Time) {
+	count := e.totalQueries.Load() - mark.total
+	latency := e.latency.snapshot().Sub(mark.latency)
+
+	var qps float64
+	if elapsed := now.Sub(mark.time).Seconds(); elapsed > 0 {
+		qps = float64(count) / elapsed
+	}
+
+	result := phaseResult{
+		stats: report.PhaseStats{
+			Name:        e.phaseName(index),
+			StartTime:   mark.time,
+			EndTime:     now,
+			Connections: e.phases[index].Connections,
+			Count:       count,
+			Errors:      e.failedQueries.Load() - mark.failed,
+			Timeouts:    e.timedOutQueries.Load() - mark.timeouts,
+			QPS:         qps,
+			Latency:     latencyStats(latency),
+		},
+		latency: latency,
+	}
+	e.logPhaseResult(result, "Phase statistics")
+
+	e.intervalsMu.Lock()
+	e.phaseResults = append(e.phaseResults, result)
+	e.intervalsMu.Unlock()
+}
+
+// logPhaseResult logs the statistics of a finished phase.
+func (e *Executor) logPhaseResult(r phaseResult, msg string) {
+	addLatencyFields(e.log.Info().
+		Str("phase", r.stats.Name).
+		Int("connections", r.stats.Connections).
+		Dur("duration", r.stats.EndTime.Sub(r.stats.StartTime)).
+		Int64("count", r.stats.Count).
+		Int64("errors", r.stats.Errors).
+		Int64("timeouts", r.stats.Timeouts).
+		Float64("qps", r.stats.QPS), r.latency).
+		Msg(msg)
+}
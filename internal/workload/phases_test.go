@@ -0,0 +1,110 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package workload
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPhaseAt(t *testing.T) {
+	phases := []Phase{
+		{Name: "ramp", Duration: 10 * time.Minute, Connections: 200, Ramp: true},
+		{Name: "hold", Duration: time.Hour, Connections: 200},
+		{Name: "step", Duration: 30 * time.Minute, Connections: 400},
+		{Name: "drain", Duration: 10 * time.Minute, Connections: 0, Ramp: true},
+	}
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		index   int
+		workers int
+	}{
+		{"start", 0, 0, 0},
+		{"mid ramp", 5 * time.Minute, 0, 100},
+		{"hold", 10 * time.Minute, 1, 200},
+		{"step", 70 * time.Minute, 2, 400},
+		{"mid drain", 105 * time.Minute, 3, 200},
+		{"end", 110 * time.Minute, 4, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index, workers := phaseAt(phases, tt.elapsed)
+			if index != tt.index || workers != tt.workers {
+				t.Errorf("Expected phase %d with %d workers, got phase %d with %d",
+					tt.index, tt.workers, index, workers)
+			}
+		})
+	}
+}
+
+func TestExecutorPhases(t *testing.T) {
+	e := newTestExecutor(t, ExecutorConfig{
+		ConnString: "host=/nonexistent",
+		Phases: []Phase{
+			{Name: "ramp", Duration: 200 * time.Millisecond, Connections: 2, Ramp: true},
+			{Duration: 200 * time.Millisecond, Connections: 4},
+		},
+	})
+	if e.connections != 4 {
+		t.Errorf("Expected peak of 4 connections, got %d", e.connections)
+	}
+
+	// Workers keep failing to connect until retired, but the schedule still
+	// runs
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := e.Run(ctx); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if ctx.Err() != nil {
+		t.Fatal("Expected run to end after the last phase")
+	}
+
+	r := e.Report()
+	if len(r.Phases) != 2 {
+		t.Fatalf("Expected 2 phases, got %d", len(r.Phases))
+	}
+	if r.Phases[0].Name != "ramp" || r.Phases[1].Name != "phase 2" {
+		t.Errorf("Unexpected phase names: %s, %s", r.Phases[0].Name, r.Phases[1].Name)
+	}
+	if r.Phases[1].Connections != 4 {
+		t.Errorf("Expected 4 connections, got %d", r.Phases[1].Connections)
+	}
+	if e.workers.Load() != 0 {
+		t.Errorf("Expected all workers retired, got %d", e.workers.Load())
+	}
+}
+
+func TestWorkerSetIDs(t *testing.T) {
+	e := newTestExecutor(t, ExecutorConfig{ConnString: "host=/nonexistent"})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// A worker started after a ramp-down gets a new ID rather than that of
+	// the retired worker
+	s := &workerSet{e: e, ctx: ctx}
+	s.resize(2)
+	s.resize(1)
+	s.resize(2)
+	if s.nextID != 3 {
+		t.Errorf("Expected 3 worker IDs handed out, got %d", s.nextID)
+	}
+	if e.workers.Load() != 2 {
+		t.Errorf("Expected 2 workers, got %d", e.workers.Load())
+	}
+
+	cancel()
+	s.resize(0)
+	s.wait()
+}
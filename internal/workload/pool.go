@@ -0,0 +1,73 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package workload
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/pgEdge/pgedge-loadgen/internal/report"
+)
+
+// acquire gets a connection from the shared pool, waiting while all of the
+// pool's connections are in use or until the worker is retired. If no
+// connection can be established, it retries with backoff like a worker
+// reconnecting. It returns the time spent waiting, which is added to the
+// latency of the query.
+func (w *workerConn) acquire(ctx context.Context, stop <-chan struct{}) (*pgxpool.Conn, time.Duration, bool) {
+	start := time.Now()
+
+	// Stop waiting for a connection when the worker is retired
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if stop != nil {
+		go func() {
+			select {
+			case <-stop:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	var conn *pgxpool.Conn
+	ok := w.withBackoff(ctx, stop, "acquire pooled connection", func() error {
+		var err error
+		conn, err = w.e.pool.Load().Acquire(ctx)
+		return err
+	})
+	if !ok {
+		return nil, 0, false
+	}
+
+	wait := time.Since(start)
+	w.e.acquireLatency.record(wait.Nanoseconds())
+	return conn, wait, true
+}
+
+// poolStats returns the totals for the shared pool, or nil if there is
+// none.
+func (e *Executor) poolStats() *report.PoolStats {
+	pool := e.pool.Load()
+	if pool == nil {
+		return nil
+	}
+	stat := pool.Stat()
+	acquireLatency := e.acquireLatency.snapshot()
+	return &report.PoolStats{
+		Size:           e.poolSize,
+		Acquires:       acquireLatency.Count(),
+		Waits:          stat.EmptyAcquireCount(),
+		WaitSeconds:    stat.EmptyAcquireWaitTime().Seconds(),
+		AcquireLatency: latencyStats(acquireLatency),
+	}
+}
@@ -0,0 +1,117 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package workload
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/pgEdge/pgedge-loadgen/internal/apps"
+)
+
+// newTestPool creates a pool shared by the executor's workers. The pool
+// connects lazily, so no server is needed until a connection is acquired.
+func newTestPool(t *testing.T, e *Executor) *pgxpool.Pool {
+	t.Helper()
+
+	pool, err := pgxpool.New(context.Background(), "host=/nonexistent")
+	if err != nil {
+		t.Fatalf("Failed to create pool: %v", err)
+	}
+	t.Cleanup(pool.Close)
+	e.pool.Store(pool)
+	return pool
+}
+
+func TestWorkerConnRunPooled(t *testing.T) {
+	e := newTestExecutor(t, ExecutorConfig{
+		PoolSize:  2,
+		Reconnect: ReconnectPolicy{MaxAttempts: 2, InitialBackoff: 10 * time.Millisecond},
+	})
+	pool := newTestPool(t, e)
+
+	w := &workerConn{e: e}
+	if w.db() != apps.DB(pool) {
+		t.Error("Expected the worker's query executor to use the pool")
+	}
+
+	// Gives up when no connection can be acquired, without running the query
+	ran := false
+	result, _, ok := w.run(context.Background(), nil, func(apps.DB) apps.QueryResult {
+		ran = true
+		return apps.QueryResult{QueryName: "new_order"}
+	})
+	if ok || ran {
+		t.Fatalf("Expected run to fail without running the query, got ok=%v ran=%v", ok, ran)
+	}
+
+	// A result without a query is not recorded
+	e.recordResult(result)
+	if n := e.totalQueries.Load(); n != 0 {
+		t.Errorf("Expected no queries recorded, got %d", n)
+	}
+
+	stats := e.poolStats()
+	if stats == nil || stats.Size != 2 || stats.Acquires != 0 {
+		t.Errorf("Pool stats mismatch: %+v", stats)
+	}
+}
+
+func TestWorkerConnAcquireRetired(t *testing.T) {
+	e := newTestExecutor(t, ExecutorConfig{PoolSize: 2})
+
+	// A pool whose connections never finish connecting, so that acquiring
+	// one waits until it is cancelled
+	config, err := pgxpool.ParseConfig("host=/nonexistent")
+	if err != nil {
+		t.Fatalf("Failed to parse pool config: %v", err)
+	}
+	config.ConnConfig.DialFunc = func(ctx context.Context, _, _ string) (net.Conn, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	pool, err := pgxpool.NewWithConfig(context.Background(), config)
+	if err != nil {
+		t.Fatalf("Failed to create pool: %v", err)
+	}
+	t.Cleanup(pool.Close)
+	e.pool.Store(pool)
+
+	// Retiring the worker stops the wait
+	stop := make(chan struct{})
+	time.AfterFunc(50*time.Millisecond, func() { close(stop) })
+	done := make(chan bool)
+	go func() {
+		_, _, ok := (&workerConn{e: e}).acquire(context.Background(), stop)
+		done <- ok
+	}()
+	select {
+	case ok := <-done:
+		if ok {
+			t.Error("Expected acquire to fail")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Acquire did not stop when the worker was retired")
+	}
+}
+
+func TestPoolStatsNoPool(t *testing.T) {
+	e := newTestExecutor(t, ExecutorConfig{})
+	if stats := e.poolStats(); stats != nil {
+		t.Errorf("Expected no pool stats, got %+v", stats)
+	}
+	if r := e.Report(); r.Pool != nil {
+		t.Errorf("Expected no pool in report, got %+v", r.Pool)
+	}
+}
@@ -0,0 +1,424 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package profiles
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Definition is a declarative usage profile, loaded from the profiles
+// section of the config file.
+//
+// The activity level at a given time is taken from the curve for that day,
+// reduced by any dips active at that time, and multiplied by the weekend
+// multiplier on Saturdays, Sundays and holidays.
+type Definition struct {
+	// Name is the profile name used with --profile.
+	Name string `mapstructure:"name"`
+
+	// Description is a human-readable description.
+	Description string `mapstructure:"description"`
+
+	// Curves holds the daily activity curves, keyed by day: a weekday name
+	// (monday ... sunday), "weekday", "weekend", "holiday" or "default".
+	// The most specific curve defined for a day is used. Holidays use the
+	// holiday curve if defined and are otherwise treated as Sundays.
+	Curves map[string]CurveDefinition `mapstructure:"curves"`
+
+	// Dips are named periods of reduced activity, such as lunch or breaks.
+	Dips []DipDefinition `mapstructure:"dips"`
+
+	// WeekendMultiplier scales activity on weekends and holidays
+	// (default 1.0).
+	WeekendMultiplier *float64 `mapstructure:"weekend_multiplier"`
+
+	// Holidays lists holiday dates, either "2006-01-02" for a single date
+	// or "01-02" for a date that recurs every year.
+	Holidays []string `mapstructure:"holidays"`
+}
+
+// CurveDefinition is a daily activity curve, given either as 24 hourly
+// levels or as piecewise-linear points. Exactly one must be set.
+type CurveDefinition struct {
+	// Hourly holds the activity level for each hour of the day, starting
+	// at midnight. The level is constant within each hour.
+	Hourly []float64 `mapstructure:"hourly"`
+
+	// Points holds activity levels at times of day. Levels between points
+	// are interpolated linearly, wrapping around midnight.
+	Points []PointDefinition `mapstructure:"points"`
+}
+
+// PointDefinition is a point of a piecewise-linear activity curve.
+type PointDefinition struct {
+	// Time is the time of day ("HH:MM").
+	Time string `mapstructure:"time"`
+
+	// Level is the activity level at that time.
+	Level float64 `mapstructure:"level"`
+}
+
+// DipDefinition is a named period of reduced activity.
+type DipDefinition struct {
+	// Name identifies the dip (e.g. "lunch").
+	Name string `mapstructure:"name"`
+
+	// Start and End are the times of day ("HH:MM") the dip starts and
+	// ends. A dip whose end is before its start wraps around midnight.
+	Start string `mapstructure:"start"`
+	End   string `mapstructure:"end"`
+
+	// Factor multiplies the activity level during the dip (e.g. 0.5).
+	Factor float64 `mapstructure:"factor"`
+
+	// Days restricts the dip to the given days: weekday names, "weekday"
+	// or "weekend". Empty means every day. Holidays count as Sundays.
+	Days []string `mapstructure:"days"`
+}
+
+const minutesPerDay = 24 * 60
+
+// holiday is the curve index used for holidays, after the seven weekdays.
+const holiday = 7
+
+var weekdayNames = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// Custom is a usage profile built from a Definition.
+type Custom struct {
+	name              string
+	description       string
+	tz                *time.Location
+	curves            [8]*curve // indexed by time.Weekday, then holiday
+	dips              []dip
+	weekendMultiplier float64
+	holidays          map[string]bool
+}
+
+type curve struct {
+	hourly  []float64
+	minutes []float64
+	levels  []float64
+}
+
+type dip struct {
+	start  float64
+	end    float64
+	factor float64
+	days   [7]bool
+}
+
+// NewCustom creates a profile from a definition.
+func NewCustom(def Definition, tz *time.Location) (Profile, error) {
+	p, err := compileDefinition(def)
+	if err != nil {
+		return nil, err
+	}
+	p.tz = tz
+	return p, nil
+}
+
+// RegisterDefinitions validates the given profile definitions and adds
+// them to the registry. Names must not clash with registered profiles.
+func RegisterDefinitions(defs []Definition) error {
+	seen := make(map[string]bool, len(defs))
+	compiled := make([]*Custom, 0, len(defs))
+
+	for _, def := range defs {
+		if _, ok := registry[def.Name]; ok || seen[def.Name] {
+			return fmt.Errorf("profile '%s' is already defined", def.Name)
+		}
+		seen[def.Name] = true
+
+		p, err := compileDefinition(def)
+		if err != nil {
+			return err
+		}
+		compiled = append(compiled, p)
+	}
+
+	for _, p := range compiled {
+		Register(p.name, func(tz *time.Location) Profile {
+			c := *p
+			c.tz = tz
+			return &c
+		})
+	}
+	return nil
+}
+
+// compileDefinition validates a definition and converts it to a profile
+// without a timezone.
+func compileDefinition(def Definition) (*Custom, error) {
+	if def.Name == "" {
+		return nil, fmt.Errorf("custom profile name is required")
+	}
+
+	p := &Custom{
+		name:              def.Name,
+		description:       def.Description,
+		weekendMultiplier: 1.0,
+		holidays:          make(map[string]bool, len(def.Holidays)),
+	}
+	if p.description == "" {
+		p.description = "Custom profile"
+	}
+
+	errorf := func(format string, args ...any) error {
+		return fmt.Errorf("profile '%s': "+format, append([]any{def.Name}, args...)...)
+	}
+
+	curves := make(map[string]*curve, len(def.Curves))
+	for key, cd := range def.Curves {
+		key = strings.ToLower(key)
+		if _, ok := weekdayNames[key]; !ok && key != "weekday" && key != "weekend" &&
+			key != "holiday" && key != "default" {
+			return nil, errorf("unknown curve day '%s'", key)
+		}
+		c, err := compileCurve(cd)
+		if err != nil {
+			return nil, errorf("curve '%s': %v", key, err)
+		}
+		curves[key] = c
+	}
+
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+		group := "weekday"
+		if day == time.Saturday || day == time.Sunday {
+			group = "weekend"
+		}
+		for _, key := range []string{name, group, "default"} {
+			if c, ok := curves[key]; ok {
+				p.curves[day] = c
+				break
+			}
+		}
+		if p.curves[day] == nil {
+			return nil, errorf("no curve defined for %s (define a 'default' curve)", name)
+		}
+	}
+	p.curves[holiday] = p.curves[time.Sunday]
+	if c, ok := curves["holiday"]; ok {
+		p.curves[holiday] = c
+	}
+
+	for _, dd := range def.Dips {
+		d, err := compileDip(dd)
+		if err != nil {
+			return nil, errorf("dip '%s': %v", dd.Name, err)
+		}
+		p.dips = append(p.dips, d)
+	}
+
+	if def.WeekendMultiplier != nil {
+		if *def.WeekendMultiplier < 0 {
+			return nil, errorf("weekend_multiplier must be non-negative")
+		}
+		p.weekendMultiplier = *def.WeekendMultiplier
+	}
+
+	for _, date := range def.Holidays {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			if _, err := time.Parse("01-02", date); err != nil {
+				return nil, errorf("invalid holiday date '%s' (expected YYYY-MM-DD or MM-DD)", date)
+			}
+		}
+		p.holidays[date] = true
+	}
+
+	return p, nil
+}
+
+func compileCurve(cd CurveDefinition) (*curve, error) {
+	switch {
+	case len(cd.Hourly) > 0 && len(cd.Points) > 0:
+		return nil, fmt.Errorf("only one of hourly or points may be set")
+	case len(cd.Hourly) > 0:
+		if len(cd.Hourly) != 24 {
+			return nil, fmt.Errorf("hourly must have 24 values, got %d", len(cd.Hourly))
+		}
+		for _, level := range cd.Hourly {
+			if level < 0 {
+				return nil, fmt.Errorf("activity levels must be non-negative")
+			}
+		}
+		return &curve{hourly: cd.Hourly}, nil
+	case len(cd.Points) > 0:
+		type point struct{ minute, level float64 }
+		points := make([]point, 0, len(cd.Points))
+		for _, pt := range cd.Points {
+			m, err := parseTimeOfDay(pt.Time)
+			if err != nil {
+				return nil, err
+			}
+			if m == minutesPerDay {
+				return nil, fmt.Errorf("point time must be before 24:00")
+			}
+			if pt.Level < 0 {
+				return nil, fmt.Errorf("activity levels must be non-negative")
+			}
+			points = append(points, point{m, pt.Level})
+		}
+		sort.Slice(points, func(i, j int) bool {
+			return points[i].minute < points[j].minute
+		})
+
+		c := &curve{}
+		for i, pt := range points {
+			if i > 0 && pt.minute == points[i-1].minute {
+				return nil, fmt.Errorf("duplicate point time %02d:%02d",
+					int(pt.minute)/60, int(pt.minute)%60)
+			}
+			c.minutes = append(c.minutes, pt.minute)
+			c.levels = append(c.levels, pt.level)
+		}
+		return c, nil
+	default:
+		return nil, fmt.Errorf("one of hourly or points is required")
+	}
+}
+
+func compileDip(dd DipDefinition) (dip, error) {
+	d := dip{factor: dd.Factor}
+
+	var err error
+	if d.start, err = parseTimeOfDay(dd.Start); err != nil {
+		return d, err
+	}
+	if d.end, err = parseTimeOfDay(dd.End); err != nil {
+		return d, err
+	}
+	if d.start == d.end {
+		return d, fmt.Errorf("start and end must differ")
+	}
+	if d.factor < 0 {
+		return d, fmt.Errorf("factor must be non-negative")
+	}
+
+	if len(dd.Days) == 0 {
+		for i := range d.days {
+			d.days[i] = true
+		}
+	}
+	for _, name := range dd.Days {
+		switch name = strings.ToLower(name); name {
+		case "weekday":
+			for day := time.Monday; day <= time.Friday; day++ {
+				d.days[day] = true
+			}
+		case "weekend":
+			d.days[time.Saturday] = true
+			d.days[time.Sunday] = true
+		default:
+			day, ok := weekdayNames[name]
+			if !ok {
+				return d, fmt.Errorf("unknown day '%s'", name)
+			}
+			d.days[day] = true
+		}
+	}
+
+	return d, nil
+}
+
+// parseTimeOfDay parses "HH:MM" (00:00 to 24:00) into minutes past midnight.
+func parseTimeOfDay(s string) (float64, error) {
+	var hour, minute int
+	if n, err := fmt.Sscanf(s, "%d:%d", &hour, &minute); err != nil || n != 2 ||
+		hour < 0 || minute < 0 || minute > 59 || hour > 24 || (hour == 24 && minute > 0) {
+		return 0, fmt.Errorf("invalid time of day '%s' (expected HH:MM)", s)
+	}
+	return float64(hour*60 + minute), nil
+}
+
+func (p *Custom) Name() string {
+	return p.name
+}
+
+func (p *Custom) Description() string {
+	return p.description
+}
+
+func (p *Custom) GetActivityLevel(t time.Time) float64 {
+	t = t.In(p.tz)
+	minute := float64(t.Hour()*60+t.Minute()) + float64(t.Second())/60.0
+	weekday := t.Weekday()
+
+	day := int(weekday)
+	if p.holidays[t.Format("2006-01-02")] || p.holidays[t.Format("01-02")] {
+		day = holiday
+		weekday = time.Sunday
+	}
+
+	level := p.curves[day].level(minute)
+
+	for _, d := range p.dips {
+		if d.days[weekday] && d.active(minute) {
+			level *= d.factor
+		}
+	}
+
+	if weekday == time.Saturday || weekday == time.Sunday {
+		level *= p.weekendMultiplier
+	}
+
+	return level
+}
+
+// level returns the curve's activity level at the given minute of the day.
+func (c *curve) level(minute float64) float64 {
+	if c.hourly != nil {
+		return c.hourly[int(minute/60)%24]
+	}
+
+	n := len(c.minutes)
+	if n == 1 {
+		return c.levels[0]
+	}
+
+	// Find the points either side of minute, wrapping around midnight.
+	i := sort.SearchFloat64s(c.minutes, minute)
+	if i < n && c.minutes[i] == minute {
+		return c.levels[i]
+	}
+	var m0, l0, m1, l1 float64
+	switch i {
+	case 0:
+		m0, l0 = c.minutes[n-1]-minutesPerDay, c.levels[n-1]
+		m1, l1 = c.minutes[0], c.levels[0]
+	case n:
+		m0, l0 = c.minutes[n-1], c.levels[n-1]
+		m1, l1 = c.minutes[0]+minutesPerDay, c.levels[0]
+	default:
+		m0, l0 = c.minutes[i-1], c.levels[i-1]
+		m1, l1 = c.minutes[i], c.levels[i]
+	}
+
+	return l0 + (l1-l0)*(minute-m0)/(m1-m0)
+}
+
+// active reports whether the dip is in effect at the given minute of the day.
+func (d dip) active(minute float64) bool {
+	if d.start < d.end {
+		return minute >= d.start && minute < d.end
+	}
+	return minute >= d.start || minute < d.end
+}
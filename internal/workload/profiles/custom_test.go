@@ -0,0 +1,190 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package profiles
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func testDefinition() Definition {
+	weekend := 0.5
+	return Definition{
+		Name:        "test-office",
+		Description: "Test office",
+		Curves: map[string]CurveDefinition{
+			"default": {Points: []PointDefinition{
+				{Time: "06:00", Level: 0.2},
+				{Time: "08:00", Level: 1.0},
+				{Time: "18:00", Level: 1.0},
+				{Time: "22:00", Level: 0.2},
+			}},
+			"Sunday": {Hourly: []float64{
+				0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.2, 0.2, 0.2, 0.2,
+				0.3, 0.3, 0.3, 0.3, 0.2, 0.2, 0.2, 0.2, 0.1, 0.1, 0.1, 0.1,
+			}},
+		},
+		Dips: []DipDefinition{
+			{Name: "lunch", Start: "12:00", End: "13:00", Factor: 0.5, Days: []string{"weekday"}},
+			{Name: "maintenance", Start: "23:30", End: "00:30", Factor: 0},
+		},
+		WeekendMultiplier: &weekend,
+		Holidays:          []string{"2026-01-05", "12-25"},
+	}
+}
+
+func TestCustomProfile(t *testing.T) {
+	p, err := NewCustom(testDefinition(), time.UTC)
+	if err != nil {
+		t.Fatalf("NewCustom failed: %v", err)
+	}
+
+	if p.Name() != "test-office" {
+		t.Errorf("Name mismatch: %s", p.Name())
+	}
+	if p.Description() != "Test office" {
+		t.Errorf("Description mismatch: %s", p.Description())
+	}
+
+	tests := []struct {
+		name     string
+		time     time.Time
+		expected float64
+	}{
+		// Wednesday
+		{"point", time.Date(2026, 1, 7, 8, 0, 0, 0, time.UTC), 1.0},
+		{"interpolated", time.Date(2026, 1, 7, 7, 0, 0, 0, time.UTC), 0.6},
+		{"wraps after last point", time.Date(2026, 1, 7, 2, 0, 0, 0, time.UTC), 0.2},
+		{"lunch dip", time.Date(2026, 1, 7, 12, 30, 0, 0, time.UTC), 0.5},
+		{"dip across midnight", time.Date(2026, 1, 7, 0, 15, 0, 0, time.UTC), 0},
+		// Saturday uses the default curve, no lunch dip
+		{"saturday", time.Date(2026, 1, 10, 12, 30, 0, 0, time.UTC), 0.5},
+		// Sunday uses the hourly curve
+		{"sunday hourly", time.Date(2026, 1, 11, 12, 59, 0, 0, time.UTC), 0.15},
+		// Monday 2026-01-05 and Christmas are holidays, treated as Sundays
+		{"dated holiday", time.Date(2026, 1, 5, 12, 30, 0, 0, time.UTC), 0.15},
+		{"recurring holiday", time.Date(2027, 12, 25, 9, 0, 0, 0, time.UTC), 0.1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level := p.GetActivityLevel(tt.time)
+			if math.Abs(level-tt.expected) > 1e-9 {
+				t.Errorf("Expected activity %.3f, got %.3f", tt.expected, level)
+			}
+		})
+	}
+}
+
+func TestCustomProfileTimezone(t *testing.T) {
+	def := testDefinition()
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("America/New_York timezone not available")
+	}
+
+	p, err := NewCustom(def, ny)
+	if err != nil {
+		t.Fatalf("NewCustom failed: %v", err)
+	}
+
+	// 13:00 UTC on a Wednesday is 08:00 in New York
+	level := p.GetActivityLevel(time.Date(2026, 1, 7, 13, 0, 0, 0, time.UTC))
+	if level != 1.0 {
+		t.Errorf("Expected activity 1.0 at 08:00 local, got %.3f", level)
+	}
+}
+
+func TestCustomProfileInvalid(t *testing.T) {
+	negative := -1.0
+
+	tests := []struct {
+		name   string
+		modify func(*Definition)
+	}{
+		{"missing name", func(d *Definition) { d.Name = "" }},
+		{"no curves", func(d *Definition) { d.Curves = nil }},
+		{"missing default", func(d *Definition) { delete(d.Curves, "default") }},
+		{"unknown day", func(d *Definition) { d.Curves["someday"] = d.Curves["default"] }},
+		{"short hourly", func(d *Definition) {
+			d.Curves["monday"] = CurveDefinition{Hourly: []float64{1, 1}}
+		}},
+		{"hourly and points", func(d *Definition) {
+			d.Curves["monday"] = CurveDefinition{
+				Hourly: d.Curves["Sunday"].Hourly,
+				Points: d.Curves["default"].Points,
+			}
+		}},
+		{"empty curve", func(d *Definition) { d.Curves["monday"] = CurveDefinition{} }},
+		{"invalid point time", func(d *Definition) {
+			d.Curves["default"] = CurveDefinition{Points: []PointDefinition{{Time: "25:00", Level: 1}}}
+		}},
+		{"duplicate point time", func(d *Definition) {
+			d.Curves["default"] = CurveDefinition{Points: []PointDefinition{
+				{Time: "08:00", Level: 1}, {Time: "8:00", Level: 0.5},
+			}}
+		}},
+		{"negative level", func(d *Definition) {
+			d.Curves["default"] = CurveDefinition{Points: []PointDefinition{{Time: "08:00", Level: -1}}}
+		}},
+		{"invalid dip time", func(d *Definition) { d.Dips[0].End = "noon" }},
+		{"empty dip", func(d *Definition) { d.Dips[0].End = d.Dips[0].Start }},
+		{"negative dip factor", func(d *Definition) { d.Dips[0].Factor = -0.5 }},
+		{"unknown dip day", func(d *Definition) { d.Dips[0].Days = []string{"funday"} }},
+		{"negative weekend multiplier", func(d *Definition) { d.WeekendMultiplier = &negative }},
+		{"invalid holiday", func(d *Definition) { d.Holidays = []string{"Christmas"} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def := testDefinition()
+			tt.modify(&def)
+			if _, err := NewCustom(def, time.UTC); err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestRegisterDefinitions(t *testing.T) {
+	def := testDefinition()
+	def.Name = "test-registered"
+
+	if err := RegisterDefinitions([]Definition{def}); err != nil {
+		t.Fatalf("RegisterDefinitions failed: %v", err)
+	}
+
+	p, err := Get("test-registered", "UTC")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if p.Name() != "test-registered" {
+		t.Errorf("Name mismatch: %s", p.Name())
+	}
+
+	// Names must not clash with registered or other custom profiles
+	if err := RegisterDefinitions([]Definition{def}); err == nil {
+		t.Error("Expected error re-registering profile")
+	}
+	builtin := testDefinition()
+	builtin.Name = "local-office"
+	if err := RegisterDefinitions([]Definition{builtin}); err == nil {
+		t.Error("Expected error for built-in profile name")
+	}
+	a, b := testDefinition(), testDefinition()
+	a.Name, b.Name = "test-duplicate", "test-duplicate"
+	if err := RegisterDefinitions([]Definition{a, b}); err == nil {
+		t.Error("Expected error for duplicate profile names")
+	}
+	if _, err := Get("test-duplicate", "UTC"); err == nil {
+		t.Error("Expected no profiles registered after error")
+	}
+}
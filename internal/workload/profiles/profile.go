@@ -12,6 +12,7 @@ package profiles
 
 import (
 	"fmt"
+	"sort"
 	"time"
 )
 
@@ -57,12 +58,13 @@ func Get(name, timezone string) (Profile, error) {
 	return constructor(loc), nil
 }
 
-// List returns all registered profile names.
+// List returns all registered profile names, sorted by name.
 func List() []string {
 	names := make([]string, 0, len(registry))
 	for name := range registry {
 		names = append(names, name)
 	}
+	sort.Strings(names)
 	return names
 }
 
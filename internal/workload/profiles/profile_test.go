@@ -10,6 +10,7 @@
 package profiles
 
 import (
+	"sort"
 	"testing"
 	"time"
 )
@@ -71,6 +72,13 @@ func TestGetAvailable(t *testing.T) {
 	}
 }
 
+func TestListSorted(t *testing.T) {
+	profiles := List()
+	if !sort.StringsAreSorted(profiles) {
+		t.Errorf("List() is not sorted: %v", profiles)
+	}
+}
+
 func TestLocalOfficeProfile(t *testing.T) {
 	profile, err := Get("local-office", "UTC")
 	if err != nil {
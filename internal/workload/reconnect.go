@@ -0,0 +1,335 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package workload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/pgEdge/pgedge-loadgen/internal/apps"
+	"github.com/pgEdge/pgedge-loadgen/internal/db"
+)
+
+const (
+	// defaultInitialBackoff is the default delay after a failed connection
+	// attempt.
+	defaultInitialBackoff = 100 * time.Millisecond
+
+	// defaultMaxBackoff is the default maximum delay between connection
+	// attempts.
+	defaultMaxBackoff = 10 * time.Second
+)
+
+// Error classes for which failed queries can be retried.
+const (
+	classSerializationFailure = "serialization_failure"
+	classDeadlock             = "deadlock"
+	classConnection           = "connection"
+)
+
+// retryClasses lists the retryable error classes.
+var retryClasses = []string{classSerializationFailure, classDeadlock, classConnection}
+
+// ReconnectPolicy controls how workers re-establish lost connections.
+type ReconnectPolicy struct {
+	MaxAttempts    int           // Consecutive failed attempts before giving up (0 = unlimited)
+	InitialBackoff time.Duration // Delay after the first failed attempt (0 = 100ms)
+	MaxBackoff     time.Duration // Limit of the doubling delay (0 = 10s)
+}
+
+// RetryPolicy sets how many times a failed query is retried, by error class.
+type RetryPolicy struct {
+	SerializationFailure int
+	Deadlock             int
+	Connection           int
+}
+
+// limit returns the number of retries for an error class.
+func (p RetryPolicy) limit(class string) int {
+	switch class {
+	case classSerializationFailure:
+		return p.SerializationFailure
+	case classDeadlock:
+		return p.Deadlock
+	case classConnection:
+		return p.Connection
+	}
+	return 0
+}
+
+// errorClass returns the retry class of a query error, or "" if the error
+// cannot be retried. lost reports whether the worker's connection was
+// closed by the error. Timeouts are not retried.
+func errorClass(err error, lost bool) string {
+	if err == nil || errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded) || isTimeout(err) {
+		return ""
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case pgErr.Code == "40001":
+			return classSerializationFailure
+		case pgErr.Code == "40P01":
+			return classDeadlock
+		case strings.HasPrefix(pgErr.Code, "08"), // connection exception
+			pgErr.Code == "57P01", // admin_shutdown
+			pgErr.Code == "57P02", // crash_shutdown
+			pgErr.Code == "57P03": // cannot_connect_now
+			return classConnection
+		}
+	}
+	if lost {
+		return classConnection
+	}
+	return ""
+}
+
+// permanentError reports whether a connection error will not go away by
+// retrying: the server rejected the login, or the database or schema does
+// not exist.
+func permanentError(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) &&
+		(strings.HasPrefix(pgErr.Code, "28") || // invalid authorization
+			pgErr.Code == "3D000" || // invalid_catalog_name
+			pgErr.Code == "3F000") // invalid_schema_name
+}
+
+// workerConn is a worker's dedicated database connection. A lost
+// connection is re-established with exponential backoff, so that workers
+// survive server restarts and failovers.
+type workerConn struct {
+	e    *Executor
+	id   int
+	conn *pgx.Conn
+	node string // address of the server the connection is to
+
+	// outage is the worker's current outage (availability mode only)
+	outage *workerOutage
+}
+
+// connect establishes the connection, retrying with backoff. It returns
+// false if the context is cancelled, the worker is retired, the attempts
+// run out or the error is permanent.
+func (w *workerConn) connect(ctx context.Context, stop <-chan struct{}) bool {
+	// Create the connection with a unique application name
+	appNameSuffix := fmt.Sprintf("client %d", w.id+1)
+	return w.withBackoff(ctx, stop, "create worker connection", func() error {
+		start := time.Now()
+		conn, err := db.ConnectSingle(ctx, w.e.connString, w.e.schema, w.e.settings, appNameSuffix)
+		if err != nil {
+			if ctx.Err() == nil {
+				w.e.connectErrors.Add(1)
+			}
+			return err
+		}
+		w.e.connectLatency.record(time.Since(start).Nanoseconds())
+		w.conn = conn
+		w.node = nodeAddr(conn)
+		return nil
+	})
+}
+
+// withBackoff calls attempt until it succeeds, backing off between failed
+// attempts according to the reconnect policy. The first failure is logged
+// as a warning and the following ones at debug level. It returns false if
+// the context is cancelled, the worker is retired, the attempts run out or
+// the error is permanent.
+func (w *workerConn) withBackoff(ctx context.Context, stop <-chan struct{},
+	action string, attempt func() error) bool {
+	policy := w.e.reconnect
+	backoff := policy.InitialBackoff
+
+	for n := 1; ; n++ {
+		err := attempt()
+		if err == nil {
+			return true
+		}
+		if ctx.Err() != nil {
+			return false
+		}
+		if permanentError(err) || (policy.MaxAttempts > 0 && n >= policy.MaxAttempts) {
+			w.e.log.Error().Err(err).
+				Int("worker_id", w.id).
+				Int("attempts", n).
+				Msgf("Failed to %s; giving up", action)
+			return false
+		}
+		logEvent := w.e.log.Debug()
+		if n == 1 {
+			logEvent = w.e.log.Warn()
+		}
+		logEvent.Err(err).
+			Int("worker_id", w.id).
+			Int("attempt", n).
+			Dur("backoff", backoff).
+			Msgf("Failed to %s; retrying", action)
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-stop:
+			return false
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, policy.MaxBackoff)
+	}
+}
+
+// nodeAddr returns the address of the server a connection is to.
+func nodeAddr(conn *pgx.Conn) string {
+	return conn.PgConn().Conn().RemoteAddr().String()
+}
+
+// reconnect replaces a lost connection. cause is the error of the query
+// that lost it; a connection closed after a query timed out is replaced
+// quietly and not counted as a reconnect.
+func (w *workerConn) reconnect(ctx context.Context, stop <-chan struct{}, cause error) bool {
+	w.conn.Close(ctx)
+	if isTimeout(cause) {
+		w.e.log.Debug().Int("worker_id", w.id).Msg("Worker connection closed by query timeout; reconnecting")
+		return w.connect(ctx, stop)
+	}
+	w.e.log.Warn().Int("worker_id", w.id).Msg("Worker connection lost; reconnecting")
+
+	start := time.Now()
+	if !w.connect(ctx, stop) {
+		return false
+	}
+	w.e.reconnects.Add(1)
+	w.e.log.Info().
+		Int("worker_id", w.id).
+		Dur("downtime", time.Since(start)).
+		Msg("Worker reconnected")
+	return true
+}
+
+// close closes the connection.
+func (w *workerConn) close(ctx context.Context) {
+	w.abandonOutage()
+	w.disconnect(ctx)
+}
+
+// disconnect closes the connection, keeping the worker's outage state so
+// that an outage can end on a later connection.
+func (w *workerConn) disconnect(ctx context.Context) {
+	if w.conn != nil {
+		w.conn.Close(ctx)
+		w.conn = nil
+	}
+}
+
+// db returns the connection, or the shared pool, that the worker's query
+// executor is created with.
+func (w *workerConn) db() apps.DB {
+	if pool := w.e.pool.Load(); pool != nil {
+		return pool
+	}
+	return w.conn
+}
+
+// run runs a query on the worker's connection or, if the workers share a
+// pool, on a connection acquired from the pool. lost reports whether the
+// connection was closed by the query. ok is false if no pooled connection
+// could be acquired, in which case no query was run.
+func (w *workerConn) run(ctx context.Context, stop <-chan struct{},
+	query func(apps.DB) apps.QueryResult) (result apps.QueryResult, lost, ok bool) {
+	if w.e.pool.Load() == nil {
+		result = query(w.conn)
+		return result, w.conn.IsClosed(), true
+	}
+
+	conn, wait, ok := w.acquire(ctx, stop)
+	if !ok {
+		return apps.QueryResult{}, false, false
+	}
+	w.node = nodeAddr(conn.Conn())
+	result = query(conn)
+	result.Duration += wait.Nanoseconds()
+
+	// A lost connection is discarded by the pool when it is released
+	lost = conn.Conn().IsClosed()
+	conn.Release()
+	return result, lost, true
+}
+
+// execute runs a random query with its timeout, retrying it according to
+// the retry policy and reconnecting if the connection was lost. The
+// duration of a retried query covers all of its attempts. It returns false
+// if the connection was lost and could not be re-established, in which
+// case the worker stops; if no query could be run, the result has no query
+// name.
+func (w *workerConn) execute(ctx context.Context, stop <-chan struct{},
+	queries apps.QueryExecutor) (apps.QueryResult, bool) {
+	start := time.Now()
+	name := queries.SelectQuery()
+	query := func(db apps.DB) apps.QueryResult {
+		return w.e.executeQuery(ctx, queries, db, name)
+	}
+	result, lost, ok := w.run(ctx, stop, query)
+	if !ok {
+		return result, false
+	}
+	node := w.node
+
+	outageErr := false
+	for retries := 0; ; retries++ {
+		class := errorClass(result.Error, lost)
+		outageErr = outageErr || isOutageError(result.Error, lost)
+
+		// A lost pooled connection is discarded by the pool instead
+		if lost && w.conn != nil && ctx.Err() == nil && !w.reconnect(ctx, stop, result.Error) {
+			ok = false
+			break
+		}
+		if class == "" || retries >= w.e.retry.limit(class) || ctx.Err() != nil {
+			break
+		}
+
+		w.e.retries[class].Add(1)
+		w.e.log.Debug().
+			Err(result.Error).
+			Str("query", result.QueryName).
+			Str("class", class).
+			Msg("Retrying query")
+		retry, retryLost, retryOK := w.run(ctx, stop, query)
+		if !retryOK {
+			ok = false
+			break
+		}
+		result, lost = retry, retryLost
+		result.Duration = time.Since(start).Nanoseconds()
+	}
+
+	if w.e.availabilityMode {
+		w.observe(result, node, start, outageErr)
+	}
+	return result, ok
+}
+
+// retryCounts returns the number of query retries by error class, and
+// their total.
+func (e *Executor) retryCounts() (map[string]int64, int64) {
+	counts := make(map[string]int64, len(e.retries))
+	var total int64
+	for class, n := range e.retries {
+		counts[class] = n.Load()
+		total += counts[class]
+	}
+	return counts, total
+}
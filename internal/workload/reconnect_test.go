@@ -0,0 +1,117 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package workload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestErrorClass(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		lost bool
+		want string
+	}{
+		{"no error", nil, false, ""},
+		{"serialization failure", &pgconn.PgError{Code: "40001"}, false, classSerializationFailure},
+		{"deadlock", &pgconn.PgError{Code: "40P01"}, false, classDeadlock},
+		{"wrapped deadlock", fmt.Errorf("new_order: %w", &pgconn.PgError{Code: "40P01"}), false, classDeadlock},
+		{"admin shutdown", &pgconn.PgError{Code: "57P01"}, true, classConnection},
+		{"connection failure", &pgconn.PgError{Code: "08006"}, false, classConnection},
+		{"connection lost", errors.New("unexpected EOF"), true, classConnection},
+		{"unique violation", &pgconn.PgError{Code: "23505"}, false, ""},
+		{"other error", errors.New("boom"), false, ""},
+		{"cancelled", context.Canceled, true, ""},
+		{"query timeout", &queryTimeoutError{timeout: time.Second}, true, ""},
+		{"statement timeout", &pgconn.PgError{Code: "57014"}, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorClass(tt.err, tt.lost); got != tt.want {
+				t.Errorf("Expected class %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPermanentError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"password authentication failed", &pgconn.PgError{Code: "28P01"}, true},
+		{"no pg_hba.conf entry", &pgconn.PgError{Code: "28000"}, true},
+		{"database does not exist", &pgconn.PgError{Code: "3D000"}, true},
+		{"schema does not exist", &pgconn.PgError{Code: "3F000"}, true},
+		{"wrapped", fmt.Errorf("failed to connect to database: %w", &pgconn.PgError{Code: "28P01"}), true},
+		{"too many clients", &pgconn.PgError{Code: "53300"}, false},
+		{"cannot connect now", &pgconn.PgError{Code: "57P03"}, false},
+		{"connection refused", errors.New("connection refused"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := permanentError(tt.err); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyLimit(t *testing.T) {
+	p := RetryPolicy{SerializationFailure: 3, Deadlock: 2, Connection: 1}
+	for class, want := range map[string]int{
+		classSerializationFailure: 3,
+		classDeadlock:             2,
+		classConnection:           1,
+		"":                        0,
+	} {
+		if got := p.limit(class); got != want {
+			t.Errorf("Expected %d retries for %q, got %d", want, class, got)
+		}
+	}
+}
+
+func TestWorkerConnConnect(t *testing.T) {
+	e := newTestExecutor(t, ExecutorConfig{
+		ConnString: "host=/nonexistent",
+		Reconnect:  ReconnectPolicy{MaxAttempts: 3, InitialBackoff: 10 * time.Millisecond},
+	})
+	if e.reconnect.MaxBackoff != defaultMaxBackoff {
+		t.Errorf("Expected default max backoff, got %v", e.reconnect.MaxBackoff)
+	}
+
+	// Gives up after the last attempt, having backed off 10ms then 20ms
+	w := &workerConn{e: e}
+	start := time.Now()
+	if w.connect(context.Background(), nil) {
+		t.Fatal("Expected connect to fail")
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("Expected at least 30ms of backoff, got %v", elapsed)
+	}
+
+	// With unlimited attempts, stops when the worker is retired
+	e.reconnect.MaxAttempts = 0
+	stop := make(chan struct{})
+	time.AfterFunc(50*time.Millisecond, func() { close(stop) })
+	if w.connect(context.Background(), stop) {
+		t.Fatal("Expected connect to fail")
+	}
+}
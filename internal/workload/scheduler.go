@@ -0,0 +1,134 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package workload
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// arrivalQueueFactor is the number of queued arrivals allowed per worker
+	// before new arrivals are dropped in target rate mode.
+	arrivalQueueFactor = 100
+
+	// minArrivalQueue is the minimum size of the arrival queue.
+	minArrivalQueue = 1000
+)
+
+// scheduler issues query arrivals at the target rate scaled by the current
+// activity level. Arrivals are queued for the workers; if the workers cannot
+// keep up, the queue grows and the schedule lag reported by the workers rises
+// rather than the arrival rate silently dropping. Arrivals that do not fit in
+// the queue are counted as dropped.
+func (e *Executor) scheduler(ctx context.Context) {
+	defer close(e.arrivals)
+
+	next := time.Now()
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		// Get current activity level from profile
+		activityLevel := e.activityLevel(next)
+		rate := e.targetQPS * activityLevel
+
+		// Pause scheduling if activity level is very low
+		if activityLevel < 0.01 || rate <= 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(100 * time.Millisecond):
+			}
+			next = time.Now()
+			continue
+		}
+
+		next = next.Add(time.Duration(float64(time.Second) / rate))
+
+		// Wait until the next arrival is due. If we are running behind
+		// (e.g. timer granularity at high rates) arrivals are issued
+		// back-to-back until we catch up with the schedule.
+		if wait := time.Until(next); wait > 0 {
+			timer.Reset(wait)
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			}
+		} else if ctx.Err() != nil {
+			return
+		}
+
+		select {
+		case e.arrivals <- next:
+		default:
+			e.droppedArrivals.Add(1)
+		}
+	}
+}
+
+// rateWorker implements target rate mode where queries are executed as
+// arrivals are issued by the scheduler rather than back-to-back. Latency is
+// measured from the scheduled arrival time, so time spent queued behind busy
+// workers is included.
+func (e *Executor) rateWorker(ctx context.Context, id int, stop <-chan struct{}) {
+	e.log.Debug().Int("worker_id", id).Msg("Rate worker started")
+
+	// Create dedicated connection for this worker, unless there is a pool
+	w := &workerConn{e: e, id: id}
+	if e.pool.Load() == nil && !w.connect(ctx, stop) {
+		return
+	}
+	defer w.close(ctx)
+
+	queries := e.app.NewQueryExecutor(ctx, w.db(), e.workerSeed(id))
+
+	for {
+		select {
+		case <-ctx.Done():
+			e.log.Debug().Int("worker_id", id).Msg("Rate worker stopped")
+			return
+		case <-stop:
+			e.log.Debug().Int("worker_id", id).Msg("Rate worker retired")
+			return
+		case scheduled, ok := <-e.arrivals:
+			if !ok {
+				e.log.Debug().Int("worker_id", id).Msg("Rate worker stopped")
+				return
+			}
+
+			lag := time.Since(scheduled).Nanoseconds()
+			if lag < 0 {
+				lag = 0
+			}
+			e.recordLag(lag)
+
+			// Execute query using the worker's connection or the pool
+			result, ok := w.execute(ctx, stop, queries)
+			result.Duration += lag
+			e.recordResult(result)
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+// recordLag updates the schedule lag metrics.
+func (e *Executor) recordLag(lagNs int64) {
+	e.totalLagNs.Add(lagNs)
+	for {
+		current := e.maxLagNs.Load()
+		if lagNs <= current || e.maxLagNs.CompareAndSwap(current, lagNs) {
+			return
+		}
+	}
+}
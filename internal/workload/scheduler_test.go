@@ -0,0 +1,170 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package workload
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// constantProfile is a usage profile with a fixed activity level.
+type constantProfile float64
+
+func (p constantProfile) Name() string                       { return "constant" }
+func (p constantProfile) Description() string                { return "Constant activity" }
+func (p constantProfile) GetActivityLevel(time.Time) float64 { return float64(p) }
+
+// runScheduler runs the executor's scheduler for d, then returns the
+// arrivals it queued.
+func runScheduler(t *testing.T, e *Executor, d time.Duration) []time.Time {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	e.scheduler(ctx)
+
+	var arrivals []time.Time
+	for scheduled := range e.arrivals {
+		arrivals = append(arrivals, scheduled)
+	}
+	return arrivals
+}
+
+func TestSchedulerPacing(t *testing.T) {
+	e := newTestExecutor(t, ExecutorConfig{TargetQPS: 200})
+	e.profile = constantProfile(0.5)
+
+	// At half activity, arrivals are due every 10ms
+	arrivals := runScheduler(t, e, 500*time.Millisecond)
+	if n := len(arrivals); n < 35 || n > 55 {
+		t.Errorf("Expected about 50 arrivals, got %d", n)
+	}
+	for i := 1; i < len(arrivals); i++ {
+		if gap := arrivals[i].Sub(arrivals[i-1]); gap != 10*time.Millisecond {
+			t.Fatalf("Expected arrivals 10ms apart, got %v between %d and %d", gap, i-1, i)
+		}
+	}
+	if n := e.droppedArrivals.Load(); n != 0 {
+		t.Errorf("Expected no dropped arrivals, got %d", n)
+	}
+}
+
+func TestSchedulerDropsArrivals(t *testing.T) {
+	e := newTestExecutor(t, ExecutorConfig{TargetQPS: 1000})
+	e.profile = constantProfile(1)
+	e.arrivals = make(chan time.Time, 5)
+
+	// Arrivals that do not fit in the queue are dropped rather than
+	// blocking the schedule
+	arrivals := runScheduler(t, e, 200*time.Millisecond)
+	if len(arrivals) != 5 {
+		t.Errorf("Expected a full queue of 5 arrivals, got %d", len(arrivals))
+	}
+	if n := e.droppedArrivals.Load(); n < 100 {
+		t.Errorf("Expected at least 100 dropped arrivals, got %d", n)
+	}
+}
+
+func TestSchedulerPausedAtLowActivity(t *testing.T) {
+	e := newTestExecutor(t, ExecutorConfig{TargetQPS: 1000})
+	e.profile = constantProfile(0)
+
+	if arrivals := runScheduler(t, e, 250*time.Millisecond); len(arrivals) != 0 {
+		t.Errorf("Expected no arrivals, got %d", len(arrivals))
+	}
+	if n := e.droppedArrivals.Load(); n != 0 {
+		t.Errorf("Expected no dropped arrivals, got %d", n)
+	}
+}
+
+func TestRecordLag(t *testing.T) {
+	e := newTestExecutor(t, ExecutorConfig{TargetQPS: 100})
+
+	for _, lag := range []int64{5, 20, 10} {
+		e.recordLag(lag * int64(time.Millisecond))
+	}
+	if total := e.totalLagNs.Load(); total != int64(35*time.Millisecond) {
+		t.Errorf("Expected 35ms of total lag, got %v", time.Duration(total))
+	}
+	if peak := e.maxLagNs.Load(); peak != int64(20*time.Millisecond) {
+		t.Errorf("Expected 20ms maximum lag, got %v", time.Duration(peak))
+	}
+}
+
+func TestRateWorkerStops(t *testing.T) {
+	tests := []struct {
+		name string
+		stop func(cancel context.CancelFunc, stop chan struct{}, arrivals chan time.Time)
+	}{
+		{
+			name: "context cancelled",
+			stop: func(cancel context.CancelFunc, _ chan struct{}, _ chan time.Time) { cancel() },
+		},
+		{
+			name: "retired",
+			stop: func(_ context.CancelFunc, stop chan struct{}, _ chan time.Time) { close(stop) },
+		},
+		{
+			name: "schedule ended",
+			stop: func(_ context.CancelFunc, _ chan struct{}, arrivals chan time.Time) { close(arrivals) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newTestExecutor(t, ExecutorConfig{TargetQPS: 100, PoolSize: 2})
+			newTestPool(t, e)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			stop := make(chan struct{})
+			done := make(chan struct{})
+			go func() {
+				e.rateWorker(ctx, 0, stop)
+				close(done)
+			}()
+
+			tt.stop(cancel, stop, e.arrivals)
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("Rate worker did not stop")
+			}
+		})
+	}
+}
+
+func TestRateWorkerRecordsLag(t *testing.T) {
+	e := newTestExecutor(t, ExecutorConfig{
+		TargetQPS: 100,
+		PoolSize:  2,
+		Reconnect: ReconnectPolicy{MaxAttempts: 1},
+	})
+	newTestPool(t, e)
+
+	// An arrival that was due 50ms ago is recorded as lag; the worker then
+	// stops as no pooled connection can be acquired
+	e.arrivals <- time.Now().Add(-50 * time.Millisecond)
+	done := make(chan struct{})
+	go func() {
+		e.rateWorker(context.Background(), 0, nil)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Rate worker did not stop")
+	}
+
+	if lag := time.Duration(e.maxLagNs.Load()); lag < 50*time.Millisecond {
+		t.Errorf("Expected at least 50ms of lag, got %v", lag)
+	}
+}
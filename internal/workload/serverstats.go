@@ -0,0 +1,330 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package workload
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+
+	"github.com/pgEdge/pgedge-loadgen/internal/db"
+	"github.com/pgEdge/pgedge-loadgen/internal/report"
+)
+
+const (
+	// topStatements is the number of statements with the highest total
+	// execution time included in the run's server statistics.
+	topStatements = 10
+
+	// serverSampleTimeout limits the time to sample the server's
+	// statistics.
+	serverSampleTimeout = 10 * time.Second
+)
+
+// serverSample is a snapshot of the server's statistics.
+type serverSample struct {
+	*db.ServerSample
+
+	// statements holds the statement statistics by query ID, or nil if
+	// pg_stat_statements is not available.
+	statements map[int64]db.StatementCounters
+}
+
+// serverStatsCollector samples the server's statistics over a connection
+// of its own, at the start of the run, every report interval and at the
+// end of the run.
+type serverStatsCollector struct {
+	e *Executor
+
+	mu         sync.Mutex
+	conn       *pgx.Conn
+	statements bool // sample pg_stat_statements
+	first      *serverSample
+	last       *serverSample
+	total      *report.ServerStats // changes over the run, once finished
+	finished   bool
+}
+
+func newServerStatsCollector(e *Executor) *serverStatsCollector {
+	return &serverStatsCollector{e: e, statements: true}
+}
+
+// sample takes a sample of the server's statistics, connecting first if
+// the collector has no connection. pg_stat_statements is no longer
+// sampled once it has failed to be read.
+func (c *serverStatsCollector) sample(ctx context.Context) (*serverSample, error) {
+	ctx, cancel := context.WithTimeout(ctx, serverSampleTimeout)
+	defer cancel()
+
+	if c.conn == nil {
+		conn, err := db.ConnectSingle(ctx, c.e.connString, c.e.schema, nil, "stats")
+		if err != nil {
+			return nil, err
+		}
+		c.conn = conn
+	}
+
+	s, err := db.SampleServerStats(ctx, c.conn, c.e.schema)
+	if err != nil {
+		c.disconnect()
+		return nil, err
+	}
+	sample := &serverSample{ServerSample: s}
+
+	if c.statements {
+		sample.statements, err = db.SampleStatementStats(ctx, c.conn)
+		switch {
+		case err == nil:
+		case c.conn.IsClosed():
+			c.disconnect()
+			return nil, err
+		default:
+			c.statements = false
+			c.e.log.Info().Err(err).
+				Msg("pg_stat_statements is not available; statement statistics disabled")
+		}
+	}
+	return sample, nil
+}
+
+// disconnect closes the collector's connection.
+func (c *serverStatsCollector) disconnect() {
+	if c.conn != nil {
+		c.conn.Close(context.Background())
+		c.conn = nil
+	}
+}
+
+// start takes the sample at the start of the run.
+func (c *serverStatsCollector) start(ctx context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, err := c.sample(ctx)
+	if err != nil {
+		c.e.log.Warn().Err(err).Msg("Failed to sample server statistics")
+		return
+	}
+	c.first, c.last = s, s
+}
+
+// interval samples the server's statistics and returns the changes since
+// the previous sample, or nil if there is no previous sample or sampling
+// failed.
+func (c *serverStatsCollector) interval(ctx context.Context) *report.ServerStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.finished {
+		return nil
+	}
+	s, err := c.sample(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			c.e.log.Warn().Err(err).Msg("Failed to sample server statistics")
+		}
+		return nil
+	}
+
+	prev := c.last
+	c.last = s
+	if prev == nil {
+		c.first = s
+		return nil
+	}
+	return serverStatsDelta(prev, s, false)
+}
+
+// finish takes the sample at the end of the run, computes the changes
+// over the run and closes the collector's connection. If the last sample
+// fails, the run's changes end at the previous sample.
+func (c *serverStatsCollector) finish() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if s, err := c.sample(context.Background()); err != nil {
+		c.e.log.Warn().Err(err).Msg("Failed to sample server statistics")
+	} else {
+		c.last = s
+	}
+	if c.first != nil && c.last != c.first {
+		c.total = serverStatsDelta(c.first, c.last, true)
+	}
+	c.finished = true
+	c.disconnect()
+}
+
+// runStats returns the changes in the server's statistics over the run,
+// or nil if the run has not finished or was not sampled.
+func (c *serverStatsCollector) runStats() *report.ServerStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.total
+}
+
+// serverStatsDelta returns the changes in the server's statistics between
+// two samples. detail adds the per-table statistics and top statements.
+func serverStatsDelta(from, to *serverSample, detail bool) *report.ServerStats {
+	s := &report.ServerStats{
+		Commits:        to.Commits - from.Commits,
+		Rollbacks:      to.Rollbacks - from.Rollbacks,
+		BlocksRead:     to.BlocksRead - from.BlocksRead,
+		BlocksHit:      to.BlocksHit - from.BlocksHit,
+		TuplesInserted: to.TuplesInserted - from.TuplesInserted,
+		TuplesUpdated:  to.TuplesUpdated - from.TuplesUpdated,
+		TuplesDeleted:  to.TuplesDeleted - from.TuplesDeleted,
+		Deadlocks:      to.Deadlocks - from.Deadlocks,
+		TempBytes:      to.TempBytes - from.TempBytes,
+	}
+	if blocks := s.BlocksRead + s.BlocksHit; blocks > 0 {
+		s.CacheHitRatio = float64(s.BlocksHit) / float64(blocks)
+	}
+	if from.WALPosition >= 0 && to.WALPosition >= 0 {
+		s.WALBytes = to.WALPosition - from.WALPosition
+	}
+	for _, r := range to.Replicas {
+		s.Replicas = append(s.Replicas, report.ReplicaLag{
+			Name:       r.Name,
+			Address:    r.Address,
+			LagBytes:   r.LagBytes,
+			LagSeconds: r.LagSeconds,
+		})
+	}
+
+	// Table statistics, sorted by name
+	names := make([]string, 0, len(to.Tables))
+	for name := range to.Tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		t, prev := to.Tables[name], from.Tables[name]
+		s.SeqScans += t.SeqScans - prev.SeqScans
+		s.IndexScans += t.IndexScans - prev.IndexScans
+		s.DeadTuples += t.DeadTuples
+		if detail {
+			s.Tables = append(s.Tables, report.TableStats{
+				Name:       name,
+				SeqScans:   t.SeqScans - prev.SeqScans,
+				IndexScans: t.IndexScans - prev.IndexScans,
+				Inserted:   t.Inserted - prev.Inserted,
+				Updated:    t.Updated - prev.Updated,
+				HotUpdated: t.HotUpdated - prev.HotUpdated,
+				Deleted:    t.Deleted - prev.Deleted,
+				Vacuums:    t.Vacuums - prev.Vacuums,
+				LiveTuples: t.LiveTuples,
+				DeadTuples: t.DeadTuples,
+			})
+		}
+	}
+
+	// Statement statistics, if both samples have them
+	if from.statements == nil || to.statements == nil {
+		return s
+	}
+	var statements []report.StatementStats
+	for id, st := range to.statements {
+		prev := from.statements[id]
+		calls := st.Calls - prev.Calls
+		if calls <= 0 {
+			continue
+		}
+		stats := report.StatementStats{
+			Query:       st.Query,
+			Calls:       calls,
+			TotalTimeMs: st.TotalTimeMs - prev.TotalTimeMs,
+			Rows:        st.Rows - prev.Rows,
+		}
+		stats.MeanTimeMs = stats.TotalTimeMs / float64(calls)
+		s.StatementCalls += stats.Calls
+		s.StatementTimeMs += stats.TotalTimeMs
+		statements = append(statements, stats)
+	}
+	if detail {
+		sort.Slice(statements, func(i, j int) bool {
+			return statements[i].TotalTimeMs > statements[j].TotalTimeMs
+		})
+		s.TopStatements = statements[:min(len(statements), topStatements)]
+	}
+	return s
+}
+
+// addServerStatsFields adds the changes in the server's statistics to a
+// log event, with the maximum replay lag if the server has replicas.
+func addServerStatsFields(logEvent *zerolog.Event, s *report.ServerStats) *zerolog.Event {
+	logEvent = logEvent.
+		Int64("commits", s.Commits).
+		Int64("rollbacks", s.Rollbacks).
+		Float64("cache_hit_ratio", s.CacheHitRatio).
+		Int64("blocks_read", s.BlocksRead).
+		Int64("tup_inserted", s.TuplesInserted).
+		Int64("tup_updated", s.TuplesUpdated).
+		Int64("tup_deleted", s.TuplesDeleted).
+		Int64("deadlocks", s.Deadlocks).
+		Int64("temp_bytes", s.TempBytes).
+		Int64("wal_bytes", s.WALBytes).
+		Int64("seq_scans", s.SeqScans).
+		Int64("idx_scans", s.IndexScans).
+		Int64("dead_tuples", s.DeadTuples)
+	if s.StatementCalls > 0 {
+		logEvent = logEvent.
+			Int64("statement_calls", s.StatementCalls).
+			Float64("avg_statement_ms", s.StatementTimeMs/float64(s.StatementCalls))
+	}
+	if len(s.Replicas) > 0 {
+		var lagBytes int64
+		var lagSeconds float64
+		for _, r := range s.Replicas {
+			lagBytes = max(lagBytes, r.LagBytes)
+			lagSeconds = max(lagSeconds, r.LagSeconds)
+		}
+		logEvent = logEvent.
+			Int("replicas", len(s.Replicas)).
+			Int64("max_replay_lag_bytes", lagBytes).
+			Float64("max_replay_lag_s", lagSeconds)
+	}
+	return logEvent
+}
+
+// logServerStats logs the changes in the server's statistics over the
+// run, for each of the app's tables and for the top statements.
+func (e *Executor) logServerStats(s *report.ServerStats) {
+	addServerStatsFields(e.log.Info(), s).Msg("Server statistics:")
+	for _, t := range s.Tables {
+		e.log.Info().
+			Str("table", t.Name).
+			Int64("seq_scans", t.SeqScans).
+			Int64("idx_scans", t.IndexScans).
+			Int64("inserted", t.Inserted).
+			Int64("updated", t.Updated).
+			Int64("hot_updated", t.HotUpdated).
+			Int64("deleted", t.Deleted).
+			Int64("vacuums", t.Vacuums).
+			Int64("live_tuples", t.LiveTuples).
+			Int64("dead_tuples", t.DeadTuples).
+			Msg("")
+	}
+	if len(s.TopStatements) > 0 {
+		e.log.Info().Msg("Top statements by total execution time:")
+		for _, st := range s.TopStatements {
+			e.log.Info().
+				Int64("calls", st.Calls).
+				Float64("total_ms", st.TotalTimeMs).
+				Float64("mean_ms", st.MeanTimeMs).
+				Int64("rows", st.Rows).
+				Str("query", st.Query).
+				Msg("")
+		}
+	}
+}
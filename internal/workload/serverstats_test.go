@@ -0,0 +1,143 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package workload
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/pgEdge/pgedge-loadgen/internal/db"
+)
+
+func TestServerStatsDelta(t *testing.T) {
+	from := &serverSample{
+		ServerSample: &db.ServerSample{
+			Commits: 100, Rollbacks: 2, BlocksRead: 10, BlocksHit: 90,
+			TuplesInserted: 50, Deadlocks: 1, WALPosition: 1000,
+			Tables: map[string]db.TableCounters{
+				"orders":    {SeqScans: 1, IndexScans: 10, Inserted: 50, DeadTuples: 5},
+				"warehouse": {SeqScans: 2, IndexScans: 20},
+			},
+		},
+		statements: map[int64]db.StatementCounters{
+			1: {Query: "SELECT 1", Calls: 10, TotalTimeMs: 10},
+			2: {Query: "UPDATE stock", Calls: 5, TotalTimeMs: 50},
+		},
+	}
+	to := &serverSample{
+		ServerSample: &db.ServerSample{
+			Commits: 300, Rollbacks: 3, BlocksRead: 30, BlocksHit: 870,
+			TuplesInserted: 80, Deadlocks: 1, WALPosition: 5096,
+			Tables: map[string]db.TableCounters{
+				"orders":    {SeqScans: 1, IndexScans: 40, Inserted: 80, DeadTuples: 12},
+				"warehouse": {SeqScans: 4, IndexScans: 20, DeadTuples: 1},
+			},
+			Replicas: []db.ReplicaStatus{{Name: "standby1", LagBytes: 512, LagSeconds: 0.25}},
+		},
+		statements: map[int64]db.StatementCounters{
+			1: {Query: "SELECT 1", Calls: 10, TotalTimeMs: 10},
+			2: {Query: "UPDATE stock", Calls: 25, TotalTimeMs: 250, Rows: 20},
+			3: {Query: "DELETE FROM history", Calls: 1, TotalTimeMs: 400, Rows: 100},
+		},
+	}
+
+	s := serverStatsDelta(from, to, true)
+	if s.Commits != 200 || s.Rollbacks != 1 || s.TuplesInserted != 30 || s.Deadlocks != 0 {
+		t.Errorf("Unexpected database counters: %+v", s)
+	}
+	if s.CacheHitRatio != 0.975 {
+		t.Errorf("Expected cache hit ratio 0.975, got %v", s.CacheHitRatio)
+	}
+	if s.WALBytes != 4096 {
+		t.Errorf("Expected 4096 WAL bytes, got %d", s.WALBytes)
+	}
+	if s.SeqScans != 2 || s.IndexScans != 30 || s.DeadTuples != 13 {
+		t.Errorf("Unexpected table totals: %d seq scans, %d index scans, %d dead tuples",
+			s.SeqScans, s.IndexScans, s.DeadTuples)
+	}
+	if len(s.Tables) != 2 || s.Tables[0].Name != "orders" || s.Tables[0].Inserted != 30 ||
+		s.Tables[0].DeadTuples != 12 {
+		t.Errorf("Unexpected tables: %+v", s.Tables)
+	}
+	if len(s.Replicas) != 1 || s.Replicas[0].LagBytes != 512 {
+		t.Errorf("Unexpected replicas: %+v", s.Replicas)
+	}
+
+	// Statements without new calls are left out; the rest are sorted by
+	// total time
+	if s.StatementCalls != 21 || s.StatementTimeMs != 600 {
+		t.Errorf("Expected 21 statement calls taking 600ms, got %d, %v", s.StatementCalls, s.StatementTimeMs)
+	}
+	if len(s.TopStatements) != 2 || s.TopStatements[0].Query != "DELETE FROM history" ||
+		s.TopStatements[1].MeanTimeMs != 10 {
+		t.Errorf("Unexpected top statements: %+v", s.TopStatements)
+	}
+
+	// Interval deltas have no per-table statistics or top statements
+	if s := serverStatsDelta(from, to, false); s.Tables != nil || s.TopStatements != nil {
+		t.Errorf("Expected no detail in interval statistics, got %+v", s)
+	}
+}
+
+func TestServerStatsDeltaWithoutStatements(t *testing.T) {
+	from := &serverSample{ServerSample: &db.ServerSample{WALPosition: -1}}
+	to := &serverSample{
+		ServerSample: &db.ServerSample{WALPosition: -1},
+		statements:   map[int64]db.StatementCounters{1: {Calls: 10}},
+	}
+
+	s := serverStatsDelta(from, to, true)
+	if s.WALBytes != 0 || s.CacheHitRatio != 0 {
+		t.Errorf("Expected no WAL bytes or cache hit ratio on a standby, got %+v", s)
+	}
+	if s.StatementCalls != 0 || s.TopStatements != nil {
+		t.Errorf("Expected no statement statistics, got %+v", s)
+	}
+}
+
+func TestTopStatementsLimit(t *testing.T) {
+	from := &serverSample{ServerSample: &db.ServerSample{}, statements: map[int64]db.StatementCounters{}}
+	to := &serverSample{ServerSample: &db.ServerSample{}, statements: map[int64]db.StatementCounters{}}
+	for i := range 2 * topStatements {
+		to.statements[int64(i)] = db.StatementCounters{
+			Query: fmt.Sprintf("SELECT %d", i), Calls: 1, TotalTimeMs: float64(i),
+		}
+	}
+
+	s := serverStatsDelta(from, to, true)
+	if len(s.TopStatements) != topStatements {
+		t.Fatalf("Expected %d top statements, got %d", topStatements, len(s.TopStatements))
+	}
+	if s.TopStatements[0].TotalTimeMs != float64(2*topStatements-1) {
+		t.Errorf("Expected the slowest statement first, got %+v", s.TopStatements[0])
+	}
+	if s.StatementCalls != int64(2*topStatements) {
+		t.Errorf("Expected totals over all statements, got %d calls", s.StatementCalls)
+	}
+}
+
+func TestServerStatsCollectorUnavailable(t *testing.T) {
+	e := newTestExecutor(t, ExecutorConfig{ConnString: "host=/nonexistent", ServerStats: true})
+
+	// Sampling failures leave the run without server statistics
+	ctx := context.Background()
+	e.serverStats.start(ctx)
+	if s := e.serverStats.interval(ctx); s != nil {
+		t.Errorf("Expected no interval statistics, got %+v", s)
+	}
+	e.serverStats.finish()
+	if s := e.serverStats.interval(ctx); s != nil {
+		t.Errorf("Expected no interval statistics after finishing, got %+v", s)
+	}
+	if r := e.Report(); r.Server != nil {
+		t.Errorf("Expected no server statistics in report, got %+v", r.Server)
+	}
+}
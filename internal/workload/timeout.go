@@ -0,0 +1,88 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package workload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/pgEdge/pgedge-loadgen/internal/apps"
+)
+
+// queryTimeoutError is the error of a query cancelled because its
+// client-side timeout expired.
+type queryTimeoutError struct {
+	timeout time.Duration
+}
+
+func (e *queryTimeoutError) Error() string {
+	return fmt.Sprintf("query timed out after %s", e.timeout)
+}
+
+// isTimeout reports whether a query error is a timeout: the query's
+// client-side timeout expired, or the server cancelled it because of
+// statement_timeout or lock_timeout. lock_timeout raises
+// lock_not_available, as does a NOWAIT lock that is not granted, so the
+// two are told apart by the message; NOWAIT failures are not timeouts.
+func isTimeout(err error) bool {
+	var timeoutErr *queryTimeoutError
+	if errors.As(err, &timeoutErr) {
+		return true
+	}
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	switch pgErr.Code {
+	case "57014": // query_canceled
+		return true
+	case "55P03": // lock_not_available
+		return strings.Contains(pgErr.Message, "lock timeout")
+	}
+	return false
+}
+
+// timeout returns the client-side timeout of a query, or 0 if it has none.
+func (e *Executor) timeout(name string) time.Duration {
+	if timeout, ok := e.queryTimeouts[name]; ok {
+		return timeout
+	}
+	return e.queryTimeout
+}
+
+// executeQuery runs a query with its client-side timeout, if it has one.
+// A query still running when the timeout expires is cancelled on the
+// server. The error of a query interrupted by the end of the run is
+// replaced by the context's error.
+func (e *Executor) executeQuery(ctx context.Context, queries apps.QueryExecutor,
+	db apps.DB, name string) apps.QueryResult {
+	qctx := ctx
+	timeout := e.timeout(name)
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		qctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	result := queries.ExecuteQuery(qctx, db, name)
+	switch {
+	case result.Error == nil:
+	case ctx.Err() != nil:
+		result.Error = ctx.Err()
+	case qctx.Err() != nil:
+		result.Error = &queryTimeoutError{timeout: timeout}
+	}
+	return result
+}
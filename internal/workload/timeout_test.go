@@ -0,0 +1,124 @@
+//-------------------------------------------------------------------------
+//
+// pgEdge Load Generator
+//
+// Copyright (c) 2025 - 2026, pgEdge, Inc.
+// This software is released under The PostgreSQL License
+//
+//-------------------------------------------------------------------------
+
+package workload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/pgEdge/pgedge-loadgen/internal/apps"
+)
+
+// blockingQueries is a query executor whose queries run until their
+// context is done.
+type blockingQueries struct{}
+
+func (blockingQueries) ExecuteRandomQuery(ctx context.Context, db apps.DB) apps.QueryResult {
+	return blockingQueries{}.ExecuteQuery(ctx, db, "delivery")
+}
+
+func (blockingQueries) SelectQuery() string {
+	return "delivery"
+}
+
+func (blockingQueries) ExecuteQuery(ctx context.Context, db apps.DB, name string) apps.QueryResult {
+	<-ctx.Done()
+	return apps.QueryResult{QueryName: name, Error: fmt.Errorf("%s: %w", name, ctx.Err())}
+}
+
+func TestIsTimeout(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"no error", nil, false},
+		{"query timeout", &queryTimeoutError{timeout: time.Second}, true},
+		{"statement timeout", fmt.Errorf("delivery: %w", &pgconn.PgError{Code: "57014"}), true},
+		{"lock timeout", &pgconn.PgError{Code: "55P03", Message: "canceling statement due to lock timeout"}, true},
+		{"nowait", &pgconn.PgError{Code: "55P03", Message: `could not obtain lock on row in relation "stock"`}, false},
+		{"deadlock", &pgconn.PgError{Code: "40P01"}, false},
+		{"deadline exceeded", context.DeadlineExceeded, false},
+		{"other error", errors.New("boom"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTimeout(tt.err); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestQueryTimeout(t *testing.T) {
+	e := newTestExecutor(t, ExecutorConfig{
+		QueryTimeout:  time.Second,
+		QueryTimeouts: map[string]time.Duration{"delivery": 5 * time.Second, "stock_level": 0},
+	})
+
+	for name, want := range map[string]time.Duration{
+		"new_order":   time.Second,
+		"delivery":    5 * time.Second,
+		"stock_level": 0,
+	} {
+		if got := e.timeout(name); got != want {
+			t.Errorf("%s: expected timeout %s, got %s", name, want, got)
+		}
+	}
+}
+
+func TestExecuteQueryTimeout(t *testing.T) {
+	e := newTestExecutor(t, ExecutorConfig{QueryTimeout: 10 * time.Millisecond})
+
+	result := e.executeQuery(context.Background(), blockingQueries{}, nil, "delivery")
+	var timeoutErr *queryTimeoutError
+	if !errors.As(result.Error, &timeoutErr) || timeoutErr.timeout != 10*time.Millisecond {
+		t.Fatalf("Expected a query timeout error, got %v", result.Error)
+	}
+
+	// A query interrupted by the end of the run is not a timeout
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result = e.executeQuery(ctx, blockingQueries{}, nil, "delivery")
+	if !errors.Is(result.Error, context.Canceled) || isTimeout(result.Error) {
+		t.Errorf("Expected the run's context error, got %v", result.Error)
+	}
+}
+
+func TestRecordResultTimeouts(t *testing.T) {
+	e := newTestExecutor(t, ExecutorConfig{})
+
+	e.recordResult(apps.QueryResult{QueryName: "delivery", Duration: int64(time.Millisecond)})
+	e.recordResult(apps.QueryResult{QueryName: "delivery", Duration: int64(time.Second),
+		Error: &queryTimeoutError{timeout: time.Second}})
+	e.recordResult(apps.QueryResult{QueryName: "delivery", Duration: int64(time.Millisecond),
+		Error: &pgconn.PgError{Code: "55P03", Message: "canceling statement due to lock timeout"}})
+	e.recordResult(apps.QueryResult{QueryName: "delivery", Duration: int64(time.Millisecond),
+		Error: errors.New("boom")})
+
+	r := e.Report()
+	if r.Summary.Count != 4 || r.Summary.Errors != 1 || r.Summary.Timeouts != 2 {
+		t.Errorf("Expected 4 queries, 1 error and 2 timeouts, got %+v", r.Summary)
+	}
+	if len(r.Queries) != 1 || r.Queries[0].Timeouts != 2 {
+		t.Errorf("Expected 2 delivery timeouts, got %+v", r.Queries)
+	}
+
+	families := gatherMetrics(t, e)
+	if v := families["pgedge_loadgen_query_timeouts_total"].GetMetric()[0].GetCounter().GetValue(); v != 2 {
+		t.Errorf("Expected 2 timeouts metric, got %v", v)
+	}
+}